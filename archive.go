@@ -0,0 +1,169 @@
+package rangechain
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io"
+
+	"github.com/halprin/rangechain/internal/generator"
+)
+
+// TarEntry is a file in a tar archive. `Content` is only valid until the next value in the chain is requested.
+type TarEntry struct {
+	Header  *tar.Header
+	Content io.Reader
+}
+
+// ZipEntry is a file in a zip archive. `Content` is opened on the first read and is only valid until the next value in the chain is requested.
+type ZipEntry struct {
+	Header  *zip.FileHeader
+	Content io.Reader
+}
+
+var gzipMagic = []byte{0x1f, 0x8b}
+
+// FromTar starts the chain with the entries of the tar archive read from `reader`. A gzip compressed archive is detected and decompressed transparently. A malformed archive generates an error.
+// Chaining and terminating methods can now be called on the result.
+func FromTar(reader io.Reader) *Link[TarEntry] {
+	var tarReader *tar.Reader
+
+	tarGenerator := func() (TarEntry, error) {
+		if tarReader == nil {
+			archiveReader, err := maybeGunzip(reader)
+			if err != nil {
+				return TarEntry{}, err
+			}
+
+			tarReader = tar.NewReader(archiveReader)
+		}
+
+		header, err := tarReader.Next()
+		if errors.Is(err, io.EOF) {
+			return TarEntry{}, generator.Exhausted
+		} else if err != nil {
+			return TarEntry{}, err
+		}
+
+		return TarEntry{Header: header, Content: tarReader}, nil
+	}
+
+	return newLink(tarGenerator)
+}
+
+func maybeGunzip(reader io.Reader) (io.Reader, error) {
+	bufferedReader := bufio.NewReader(reader)
+
+	magic, err := bufferedReader.Peek(len(gzipMagic))
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+
+	if bytes.Equal(magic, gzipMagic) {
+		return gzip.NewReader(bufferedReader)
+	}
+
+	return bufferedReader, nil
+}
+
+// FromZip starts the chain with the files of the zip archive `zipReader`, in the order they appear in the archive's directory.
+// Chaining and terminating methods can now be called on the result.
+func FromZip(zipReader *zip.Reader) *Link[ZipEntry] {
+	currentIndex := 0
+	var previousContent *lazyZipContent
+
+	zipGenerator := func() (ZipEntry, error) {
+		if previousContent != nil {
+			err := previousContent.close()
+			previousContent = nil
+			if err != nil {
+				return ZipEntry{}, err
+			}
+		}
+
+		if currentIndex >= len(zipReader.File) {
+			return ZipEntry{}, generator.Exhausted
+		}
+
+		file := zipReader.File[currentIndex]
+		currentIndex++
+
+		previousContent = &lazyZipContent{file: file}
+
+		return ZipEntry{Header: &file.FileHeader, Content: previousContent}, nil
+	}
+
+	return newLink(zipGenerator)
+}
+
+// lazyZipContent opens a zip file on the first read, so entries whose content is never read aren't decompressed.
+type lazyZipContent struct {
+	file   *zip.File
+	reader io.ReadCloser
+	closed bool
+}
+
+func (receiver *lazyZipContent) Read(buffer []byte) (int, error) {
+	if receiver.closed {
+		return 0, errors.New("zip entry content read after moving to the next entry")
+	}
+
+	if receiver.reader == nil {
+		reader, err := receiver.file.Open()
+		if err != nil {
+			return 0, err
+		}
+		receiver.reader = reader
+	}
+
+	return receiver.reader.Read(buffer)
+}
+
+func (receiver *lazyZipContent) close() error {
+	receiver.closed = true
+
+	if receiver.reader == nil {
+		return nil
+	}
+
+	return receiver.reader.Close()
+}
+
+// WriteTar writes each value in the chain as a file of a tar archive to `writer`. `toEntry` converts a value into the header and content of its file; the content must be exactly `Header.Size` bytes. Use `func(entry TarEntry) (TarEntry, error) { return entry, nil }` to copy the chain from `FromTar`. The archive is finished, but `writer` isn't closed. Stops on the first error, from either the chain, `toEntry`, or the writing, and returns it.
+func (receiver *Link[T]) WriteTar(writer io.Writer, toEntry func(T) (TarEntry, error)) error {
+	defer receiver.finished(receiver.currentClock().Now())
+
+	tarWriter := tar.NewWriter(writer)
+
+	for {
+		currentValue, err := receiver.generator()
+		if err != nil {
+			if errors.Is(err, generator.Exhausted) {
+				break
+			}
+			return err
+		}
+
+		entry, err := toEntry(currentValue)
+		if err != nil {
+			return err
+		}
+
+		err = tarWriter.WriteHeader(entry.Header)
+		if err != nil {
+			return err
+		}
+
+		if entry.Content != nil {
+			_, err = io.Copy(tarWriter, entry.Content)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return tarWriter.Close()
+}
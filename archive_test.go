@@ -0,0 +1,119 @@
+package rangechain
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testArchiveFiles = []Pair[string, string]{
+	{Left: "DogCow.txt", Right: "Moof!"},
+	{Left: "Clarus.txt", Right: "Moof! Moof!"},
+}
+
+func createTestTar(t *testing.T) []byte {
+	var archive bytes.Buffer
+
+	err := FromSlice(testArchiveFiles).WriteTar(&archive, func(file Pair[string, string]) (TarEntry, error) {
+		header := &tar.Header{Name: file.Left, Mode: 0o644, Size: int64(len(file.Right))}
+		return TarEntry{Header: header, Content: strings.NewReader(file.Right)}, nil
+	})
+	assert.Nil(t, err)
+
+	return archive.Bytes()
+}
+
+func readTarEntry(entry TarEntry) (Pair[string, string], error) {
+	content, err := io.ReadAll(entry.Content)
+	return Pair[string, string]{Left: entry.Header.Name, Right: string(content)}, err
+}
+
+func TestFromTar(t *testing.T) {
+	assert := assert.New(t)
+
+	actualSlice, err := FromTar(bytes.NewReader(createTestTar(t))).Map(readTarEntry).Slice()
+
+	assert.Equal(testArchiveFiles, actualSlice)
+	assert.Nil(err)
+}
+
+func TestFromTarWithGzip(t *testing.T) {
+	assert := assert.New(t)
+
+	var compressed bytes.Buffer
+	gzipWriter := gzip.NewWriter(&compressed)
+	_, err := gzipWriter.Write(createTestTar(t))
+	assert.Nil(err)
+	assert.Nil(gzipWriter.Close())
+
+	actualSlice, err := FromTar(&compressed).Map(readTarEntry).Slice()
+
+	assert.Equal(testArchiveFiles, actualSlice)
+	assert.Nil(err)
+}
+
+func TestFromTarEmpty(t *testing.T) {
+	assert := assert.New(t)
+
+	actualSlice, err := FromTar(bytes.NewReader(nil)).Slice()
+
+	assert.Equal([]TarEntry{}, actualSlice)
+	assert.Nil(err)
+}
+
+func TestWriteTarRoundTrip(t *testing.T) {
+	assert := assert.New(t)
+
+	var copied bytes.Buffer
+	err := FromTar(bytes.NewReader(createTestTar(t))).WriteTar(&copied, func(entry TarEntry) (TarEntry, error) {
+		return entry, nil
+	})
+	assert.Nil(err)
+
+	actualSlice, err := FromTar(&copied).Map(readTarEntry).Slice()
+
+	assert.Equal(testArchiveFiles, actualSlice)
+	assert.Nil(err)
+}
+
+func TestWriteTarHasError(t *testing.T) {
+	expectedError := errors.New("an example error")
+
+	err := FromSlice(testArchiveFiles).WriteTar(io.Discard, func(file Pair[string, string]) (TarEntry, error) {
+		return TarEntry{}, expectedError
+	})
+
+	assert.Equal(t, expectedError, err)
+}
+
+func TestFromZip(t *testing.T) {
+	assert := assert.New(t)
+
+	var archive bytes.Buffer
+	zipWriter := zip.NewWriter(&archive)
+	for _, file := range testArchiveFiles {
+		fileWriter, err := zipWriter.Create(file.Left)
+		assert.Nil(err)
+		_, err = fileWriter.Write([]byte(file.Right))
+		assert.Nil(err)
+	}
+	assert.Nil(zipWriter.Close())
+
+	zipReader, err := zip.NewReader(bytes.NewReader(archive.Bytes()), int64(archive.Len()))
+	assert.Nil(err)
+
+	actualSlice, err := FromZip(zipReader).Map(func(entry ZipEntry) (Pair[string, string], error) {
+		content, err := io.ReadAll(entry.Content)
+		return Pair[string, string]{Left: entry.Header.Name, Right: string(content)}, err
+	}).Slice()
+
+	assert.Equal(testArchiveFiles, actualSlice)
+	assert.Nil(err)
+}
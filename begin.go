@@ -1,9 +1,13 @@
 package rangechain
 
 import (
+	"bufio"
+	"io"
 	"iter"
+	"time"
 
 	"github.com/halprin/rangechain/internal/generator"
+	"github.com/halprin/rangechain/internal/helper"
 	"github.com/halprin/rangechain/keyvalue"
 )
 
@@ -36,3 +40,130 @@ func FromIterator[T any](anIterator iter.Seq[T]) *Link[T] {
 func FromSeq2[K, V any](seq iter.Seq2[K, V]) *Link[keyvalue.KeyValuer[K, V]] {
 	return newLink(generator.FromSeq2(seq))
 }
+
+// Number is the set of integer and floating point types `Range` can count with.
+type Number = generator.Number
+
+// Range starts the chain with the numbers from `start` up to, but not including, `end`, counting by `step`. A negative `step` counts down; an unsigned `N` can't take a negative `step`, so it only counts up, and a `start` past `end` gives an empty chain. Counting stops before a value that doesn't fit in `N`. Each value is computed lazily, so nothing is allocated up front. A zero `step` generates `ErrZeroStep`.
+// Chaining and terminating methods can now be called on the result.
+func Range[N Number](start N, end N, step N) *Link[N] {
+	return newLink(generator.FromRange(start, end, step))
+}
+
+// ErrZeroStep is generated by `Range` and `TimeRange` when `step` is zero, which would never reach `end`.
+var ErrZeroStep = generator.ErrZeroStep
+
+// Naturals starts an infinite chain counting up from zero. Use `Limit` or a short-circuiting terminating method to stop it.
+// Chaining and terminating methods can now be called on the result.
+func Naturals() *Link[int] {
+	return newLink(generator.Naturals())
+}
+
+// TimeRange starts the chain with the times from `start` up to, but not including, `end`, stepping by `step`. A negative `step` steps backwards in time. Each value is computed lazily. A zero `step` generates `ErrZeroStep`.
+// Chaining and terminating methods can now be called on the result.
+func TimeRange(start time.Time, end time.Time, step time.Duration) *Link[time.Time] {
+	return newLink(generator.FromTimeRange(start, end, step))
+}
+
+// Exhausted is returned by a `Generate` supplier to end the chain. Any other error is passed down the chain as normal.
+var Exhausted = generator.Exhausted
+
+// Generate starts the chain by calling `supplier` for each value until it returns `Exhausted`.
+// Chaining and terminating methods can now be called on the result.
+func Generate[T any](supplier func() (T, error)) *Link[T] {
+	return newLink(supplier)
+}
+
+// Iterate starts an infinite chain with `seed`, followed by `next` applied to the previous value. Use `Limit` or a short-circuiting terminating method to stop it.
+// Chaining and terminating methods can now be called on the result.
+func Iterate[T any](seed T, next func(T) T) *Link[T] {
+	return newLink(generator.Iterate(seed, next))
+}
+
+// Repeat starts the chain with `value` repeated `times` times. A negative `times` repeats forever.
+// Chaining and terminating methods can now be called on the result.
+func Repeat[T any](value T, times int) *Link[T] {
+	return newLink(generator.Repeat(value, times))
+}
+
+// Unfold starts the chain by repeatedly calling `step` with the current state, starting with `seed`. `step` returns the next value, the next state, and whether a value was produced; returning false ends the chain.
+// Chaining and terminating methods can now be called on the result.
+func Unfold[S, T any](seed S, step func(S) (T, S, bool, error)) *Link[T] {
+	return newLink(generator.Unfold(seed, step))
+}
+
+// TraversalOrder is the order nodes are visited by `Traverse`.
+type TraversalOrder = generator.TraversalOrder
+
+const (
+	// BFS visits a node, then all nodes one level deeper, and so on.
+	BFS = generator.BFS
+	// DFSPre visits a node before its children, going as deep as possible first.
+	DFSPre = generator.DFSPre
+	// DFSPost visits a node after its children, going as deep as possible first.
+	DFSPost = generator.DFSPost
+)
+
+// Traverse starts the chain with the nodes of the tree rooted at `root`, in the supplied `order`. `children` is called lazily, so `Limit` or a short-circuiting terminating method stops the traversal early. Every node is visited as often as it is reached; use `TraverseDistinct` for graphs with cycles.
+// Chaining and terminating methods can now be called on the result.
+func Traverse[T any](root T, children func(T) ([]T, error), order TraversalOrder) *Link[T] {
+	return newLink(generator.Traverse(root, children, order, nil))
+}
+
+// TraverseDistinct is like Traverse, but only visits the first node with a given key. The key is computed with `keyFunction`. This stops cycles in a graph from being walked forever.
+// Chaining and terminating methods can now be called on the result.
+func TraverseDistinct[T any, K comparable](root T, children func(T) ([]T, error), order TraversalOrder, keyFunction func(T) K) *Link[T] {
+	seenTracker := helper.NewSet[K]()
+
+	discover := func(node T) bool {
+		key := keyFunction(node)
+		if seenTracker.Contains(key) {
+			return false
+		}
+
+		seenTracker.Add(key)
+		return true
+	}
+
+	return newLink(generator.Traverse(root, children, order, discover))
+}
+
+// FromReaderLines starts the chain with the lines read from `reader`, without their line endings. A read error is passed down the chain.
+// Chaining and terminating methods can now be called on the result.
+func FromReaderLines(reader io.Reader) *Link[string] {
+	return FromScanner(bufio.NewScanner(reader))
+}
+
+// FromReaderLinesWithLimit is like FromReaderLines, but allows lines up to `maxLineLength` bytes, not counting the line ending. A longer line generates `bufio.ErrTooLong`.
+// Chaining and terminating methods can now be called on the result.
+func FromReaderLinesWithLimit(reader io.Reader, maxLineLength int) *Link[string] {
+	// the buffer also has to hold the line ending, which can be "\r\n"
+	bufferSize := maxLineLength + 2
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 0, min(bufferSize, bufio.MaxScanTokenSize)), bufferSize)
+	scanner.Split(func(data []byte, atEOF bool) (int, []byte, error) {
+		advance, token, err := bufio.ScanLines(data, atEOF)
+		if len(token) > maxLineLength {
+			return 0, nil, bufio.ErrTooLong
+		}
+
+		return advance, token, err
+	})
+
+	return FromScanner(scanner)
+}
+
+// FromScanner starts the chain with the tokens of the supplied `bufio.Scanner`, so any split function can be used. An error reported by `Scanner.Err` is passed down the chain.
+// Chaining and terminating methods can now be called on the result.
+func FromScanner(scanner *bufio.Scanner) *Link[string] {
+	return newLink(generator.FromScanner(scanner))
+}
+
+// ErrInvalidChunkSize is generated by `FromReaderChunks` when `size` is less than one.
+var ErrInvalidChunkSize = generator.ErrInvalidChunkSize
+
+// FromReaderChunks starts the chain with blocks of `size` bytes read from `reader`. The last block is shorter if the reader runs out partway. Each block is a new slice. A read error is passed down the chain. A `size` less than one generates `ErrInvalidChunkSize`.
+// Chaining and terminating methods can now be called on the result.
+func FromReaderChunks(reader io.Reader, size int) *Link[[]byte] {
+	return newLink(generator.FromReaderChunks(reader, size))
+}
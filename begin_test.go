@@ -1,9 +1,13 @@
 package rangechain
 
 import (
+	"bufio"
+	"errors"
 	"maps"
 	"slices"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/halprin/rangechain/keyvalue"
 	"github.com/stretchr/testify/assert"
@@ -152,3 +156,251 @@ func assertEqualsBasedOnKeyValuerInterface[K comparable, V comparable](t *testin
 		assert.True(foundMatch)
 	}
 }
+
+func TestRange(t *testing.T) {
+	assert := assert.New(t)
+
+	slice, err := Range(0, 10, 3).Slice()
+	assert.Equal([]int{0, 3, 6, 9}, slice)
+	assert.Nil(err)
+}
+
+func TestRangeWithFloats(t *testing.T) {
+	assert := assert.New(t)
+
+	slice, err := Range(0.0, 1.0, 0.25).Slice()
+	assert.Equal([]float64{0, 0.25, 0.5, 0.75}, slice)
+	assert.Nil(err)
+}
+
+func TestRangeEmpty(t *testing.T) {
+	assert := assert.New(t)
+
+	slice, err := Range(5, 5, 1).Slice()
+	assert.Equal([]int{}, slice)
+	assert.Nil(err)
+}
+
+func TestRangeStopsBeforeOverflow(t *testing.T) {
+	assert := assert.New(t)
+
+	slice, err := Range[uint8](250, 255, 10).Slice()
+	assert.Equal([]uint8{250}, slice)
+	assert.Nil(err)
+}
+
+func TestRangeUnsignedOnlyCountsUp(t *testing.T) {
+	assert := assert.New(t)
+
+	actualSlice, err := Range[uint](5, 1, 1).Slice()
+
+	assert.Equal([]uint{}, actualSlice)
+	assert.Nil(err)
+}
+
+func TestRangeWithZeroStep(t *testing.T) {
+	assert := assert.New(t)
+
+	slice, err := Range(0, 10, 0).Slice()
+	assert.Equal([]int{}, slice)
+	assert.ErrorIs(err, ErrZeroStep)
+}
+
+func TestNaturals(t *testing.T) {
+	assert := assert.New(t)
+
+	slice, err := Naturals().Limit(5).Slice()
+	assert.Equal([]int{0, 1, 2, 3, 4}, slice)
+	assert.Nil(err)
+}
+
+func TestTimeRange(t *testing.T) {
+	assert := assert.New(t)
+
+	start := time.Date(1984, time.January, 24, 0, 0, 0, 0, time.UTC)
+	end := start.Add(3 * time.Hour)
+
+	slice, err := TimeRange(start, end, time.Hour).Slice()
+	assert.Equal([]time.Time{start, start.Add(time.Hour), start.Add(2 * time.Hour)}, slice)
+	assert.Nil(err)
+}
+
+func TestTimeRangeBackwards(t *testing.T) {
+	assert := assert.New(t)
+
+	start := time.Date(1984, time.January, 24, 0, 0, 0, 0, time.UTC)
+	end := start.Add(-2 * time.Hour)
+
+	slice, err := TimeRange(start, end, -time.Hour).Slice()
+	assert.Equal([]time.Time{start, start.Add(-time.Hour)}, slice)
+	assert.Nil(err)
+}
+
+func TestGenerate(t *testing.T) {
+	assert := assert.New(t)
+
+	next := 0
+	supplier := func() (int, error) {
+		if next >= 3 {
+			return 0, Exhausted
+		}
+		next++
+		return next * 10, nil
+	}
+
+	slice, err := Generate(supplier).Slice()
+	assert.Equal([]int{10, 20, 30}, slice)
+	assert.Nil(err)
+}
+
+func TestGenerateHasError(t *testing.T) {
+	assert := assert.New(t)
+
+	expectedError := errors.New("an example error")
+	supplier := func() (int, error) {
+		return 0, expectedError
+	}
+
+	_, err := Generate(supplier).Slice()
+	assert.Equal(expectedError, err)
+}
+
+func TestIterate(t *testing.T) {
+	assert := assert.New(t)
+
+	slice, err := Iterate(1, func(value int) int { return value * 2 }).Limit(5).Slice()
+	assert.Equal([]int{1, 2, 4, 8, 16}, slice)
+	assert.Nil(err)
+}
+
+func TestRepeat(t *testing.T) {
+	assert := assert.New(t)
+
+	slice, err := Repeat("Moof!", 3).Slice()
+	assert.Equal([]string{"Moof!", "Moof!", "Moof!"}, slice)
+	assert.Nil(err)
+}
+
+func TestRepeatForever(t *testing.T) {
+	assert := assert.New(t)
+
+	count, err := Repeat("Moof!", -1).Limit(100).Count()
+	assert.Equal(100, count)
+	assert.Nil(err)
+}
+
+func TestUnfold(t *testing.T) {
+	assert := assert.New(t)
+
+	fibonacci := func(state [2]int) (int, [2]int, bool, error) {
+		return state[0], [2]int{state[1], state[0] + state[1]}, state[0] < 20, nil
+	}
+
+	slice, err := Unfold([2]int{0, 1}, fibonacci).Slice()
+	assert.Equal([]int{0, 1, 1, 2, 3, 5, 8, 13}, slice)
+	assert.Nil(err)
+}
+
+func TestTraverseWithAnyMatchStopsEarly(t *testing.T) {
+	assert := assert.New(t)
+
+	var expanded []int
+	children := func(node int) ([]int, error) {
+		expanded = append(expanded, node)
+		return []int{node * 2, node*2 + 1}, nil
+	}
+
+	match, err := Traverse(1, children, BFS).AnyMatch(func(value int) (bool, error) { return value == 3, nil })
+	assert.True(match)
+	assert.Nil(err)
+	assert.Equal([]int{1}, expanded)
+}
+
+func TestTraverseDistinctWithCycle(t *testing.T) {
+	assert := assert.New(t)
+
+	graph := map[string][]string{
+		"DogCow": {"Moof", "Clarus"},
+		"Moof":   {"DogCow", "Clarus"},
+		"Clarus": {"DogCow"},
+	}
+	children := func(node string) ([]string, error) {
+		return graph[node], nil
+	}
+
+	slice, err := TraverseDistinct("DogCow", children, DFSPre, func(node string) string { return node }).Slice()
+	assert.Equal([]string{"DogCow", "Moof", "Clarus"}, slice)
+	assert.Nil(err)
+}
+
+func TestTraverseDistinctPostOrderWithCycle(t *testing.T) {
+	assert := assert.New(t)
+
+	graph := map[int][]int{1: {2}, 2: {3}, 3: {1}}
+	children := func(node int) ([]int, error) {
+		return graph[node], nil
+	}
+
+	slice, err := TraverseDistinct(1, children, DFSPost, func(node int) int { return node }).Slice()
+	assert.Equal([]int{3, 2, 1}, slice)
+	assert.Nil(err)
+}
+
+func TestFromReaderLines(t *testing.T) {
+	assert := assert.New(t)
+
+	slice, err := FromReaderLines(strings.NewReader("DogCows\ngoes\r\nMoof!")).Slice()
+	assert.Equal([]string{"DogCows", "goes", "Moof!"}, slice)
+	assert.Nil(err)
+}
+
+func TestFromReaderLinesWithLimit(t *testing.T) {
+	assert := assert.New(t)
+
+	slice, err := FromReaderLinesWithLimit(strings.NewReader("Moof!\nDogCows go Moof!\n"), 10).Slice()
+	assert.Equal([]string{"Moof!"}, slice)
+	assert.ErrorIs(err, bufio.ErrTooLong)
+}
+
+func TestFromReaderLinesWithLimitAtBoundary(t *testing.T) {
+	assert := assert.New(t)
+
+	slice, err := FromReaderLinesWithLimit(strings.NewReader("abcde\nxy\r\nvwxyz\r\nfghij"), 5).Slice()
+	assert.Equal([]string{"abcde", "xy", "vwxyz", "fghij"}, slice)
+	assert.Nil(err)
+
+	slice, err = FromReaderLinesWithLimit(strings.NewReader("abcde\nabcdef\n"), 5).Slice()
+	assert.Equal([]string{"abcde"}, slice)
+	assert.ErrorIs(err, bufio.ErrTooLong)
+
+	slice, err = FromReaderLinesWithLimit(strings.NewReader("abcdef"), 5).Slice()
+	assert.Equal([]string{}, slice)
+	assert.ErrorIs(err, bufio.ErrTooLong)
+}
+
+func TestFromScanner(t *testing.T) {
+	assert := assert.New(t)
+
+	scanner := bufio.NewScanner(strings.NewReader("DogCows goes  Moof!"))
+	scanner.Split(bufio.ScanWords)
+
+	slice, err := FromScanner(scanner).Slice()
+	assert.Equal([]string{"DogCows", "goes", "Moof!"}, slice)
+	assert.Nil(err)
+}
+
+func TestFromReaderChunks(t *testing.T) {
+	assert := assert.New(t)
+
+	slice, err := FromReaderChunks(strings.NewReader("DogCowMoof!"), 6).Slice()
+	assert.Equal([][]byte{[]byte("DogCow"), []byte("Moof!")}, slice)
+	assert.Nil(err)
+}
+
+func TestFromReaderChunksWithZeroSize(t *testing.T) {
+	assert := assert.New(t)
+
+	slice, err := FromReaderChunks(strings.NewReader("DogCow"), 0).Limit(3).Slice()
+	assert.Equal([][]byte{}, slice)
+	assert.ErrorIs(err, ErrInvalidChunkSize)
+}
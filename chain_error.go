@@ -0,0 +1,31 @@
+package rangechain
+
+import "fmt"
+
+// ChainError is generated when the function passed to a chaining method returns an error. It records where in the chain the error happened. `errors.Is` and `errors.As` see through it to `Err`.
+type ChainError struct {
+	// Stage is the name of the chaining method, like "Map" or "Filter", or the label set with `Label`.
+	Stage string
+	// Index is the zero-based position of the failing value among the values the chaining method received.
+	Index int
+	// Value is the value the function failed on.
+	Value any
+	// Err is the error the function returned.
+	Err error
+}
+
+func (receiver *ChainError) Error() string {
+	return fmt.Sprintf("%s: element %d (%v): %v", receiver.Stage, receiver.Index, receiver.Value, receiver.Err)
+}
+
+func (receiver *ChainError) Unwrap() error {
+	return receiver.Err
+}
+
+// Label names the chaining method called right after it. The name is used instead of the method's name in a `ChainError`.
+func (receiver *Link[T]) Label(name string) *Link[T] {
+	link := *receiver
+	link.label = name
+
+	return &link
+}
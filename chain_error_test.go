@@ -0,0 +1,77 @@
+package rangechain
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChainErrorFromMap(t *testing.T) {
+	assert := assert.New(t)
+
+	_, err := FromSlice([]string{"1", "2", "Moof!"}).Map(strconv.Atoi).Slice()
+
+	var chainError *ChainError
+	assert.True(errors.As(err, &chainError))
+	assert.Equal("Map", chainError.Stage)
+	assert.Equal(2, chainError.Index)
+	assert.Equal("Moof!", chainError.Value)
+	assert.ErrorIs(err, strconv.ErrSyntax)
+	assert.Contains(err.Error(), "Map: element 2 (Moof!)")
+}
+
+func TestChainErrorWithLabel(t *testing.T) {
+	assert := assert.New(t)
+
+	_, err := FromSlice([]string{"1", "Moof!"}).
+		Label("parse").
+		Map(strconv.Atoi).
+		Filter(func(value int) (bool, error) { return true, nil }).
+		Slice()
+
+	var chainError *ChainError
+	assert.True(errors.As(err, &chainError))
+	assert.Equal("parse", chainError.Stage)
+	assert.Equal(1, chainError.Index)
+}
+
+func TestChainErrorLabelOnlyAppliesToNextMethod(t *testing.T) {
+	assert := assert.New(t)
+
+	_, err := FromSlice([]string{"1", "2"}).
+		Label("parse").
+		Map(strconv.Atoi).
+		Map(func(value int) (int, error) { return 0, errors.New("an example error") }).
+		Slice()
+
+	var chainError *ChainError
+	assert.True(errors.As(err, &chainError))
+	assert.Equal("Map", chainError.Stage)
+	assert.Equal(0, chainError.Index)
+	assert.Equal(1, chainError.Value)
+}
+
+func TestChainErrorFromFlatten(t *testing.T) {
+	assert := assert.New(t)
+
+	_, err := FromSlice([]any{[]int{1, 2}, "oops"}).Flatten[int]().Slice()
+
+	var chainError *ChainError
+	assert.True(errors.As(err, &chainError))
+	assert.Equal("Flatten", chainError.Stage)
+	assert.Equal(2, chainError.Index)
+	assert.Equal("oops", chainError.Value)
+}
+
+func TestChainErrorIsCollected(t *testing.T) {
+	assert := assert.New(t)
+
+	_, err := FromSlice([]string{"DogCow", "1", "Moof!"}).OnError(CollectAll()).Map(strconv.Atoi).Slice()
+
+	collected := err.(interface{ Unwrap() []error }).Unwrap()
+	assert.Len(collected, 2)
+	assert.Equal(0, collected[0].(*ChainError).Index)
+	assert.Equal(2, collected[1].(*ChainError).Index)
+}
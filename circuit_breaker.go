@@ -0,0 +1,163 @@
+package rangechain
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// CircuitState is the state of a circuit breaker made by `WithCircuitBreaker`.
+type CircuitState int
+
+const (
+	// CircuitClosed lets every call through.
+	CircuitClosed CircuitState = iota
+	// CircuitOpen fails every call without making it.
+	CircuitOpen
+	// CircuitHalfOpen lets a single trial call through to decide whether to close or open again.
+	CircuitHalfOpen
+)
+
+func (receiver CircuitState) String() string {
+	switch receiver {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	default:
+		return fmt.Sprintf("CircuitState(%d)", int(receiver))
+	}
+}
+
+// CircuitBreakerConfig configures `WithCircuitBreaker`.
+type CircuitBreakerConfig struct {
+	// FailureThreshold is how many consecutive failures open the circuit. Zero or less means 1.
+	FailureThreshold int
+	// Cooldown is how long the circuit stays open before letting a trial call through.
+	Cooldown time.Duration
+	// OnStateChange, if not nil, is called with every change of state, in order. It is called while the circuit breaker is locked, so it should return quickly and not call the wrapped function.
+	OnStateChange func(from CircuitState, to CircuitState)
+	// Clock, if not nil, replaces the system clock for measuring the cooldown.
+	Clock Clock
+}
+
+// CircuitOpenError is returned instead of calling the function wrapped by `WithCircuitBreaker` while the circuit is open.
+type CircuitOpenError struct {
+	// Value is the value the function wasn't called with.
+	Value any
+	// RetryAt is when the circuit lets a trial call through. While a trial call is in progress, it is the time the call was let through.
+	RetryAt time.Time
+}
+
+func (receiver *CircuitOpenError) Error() string {
+	return fmt.Sprintf("circuit open until %v: element (%v) not attempted", receiver.RetryAt.Format(time.RFC3339Nano), receiver.Value)
+}
+
+// WithCircuitBreaker wraps `function` in a circuit breaker. After `config.FailureThreshold` consecutive failures, the circuit opens and calls fail right away with a `*CircuitOpenError`. Once `config.Cooldown` has passed, the circuit is half-open and lets one trial call through: success closes the circuit, failure opens it again. The result is safe to pass to `Map` or `MapParallel`; all calls share one circuit. A call that panics counts as a failure, and the panic carries on.
+func WithCircuitBreaker[T, U any](function func(T) (U, error), config CircuitBreakerConfig) func(T) (U, error) {
+	breaker := &circuitBreaker{config: config, clock: config.Clock}
+	if breaker.clock == nil {
+		breaker.clock = systemClock{}
+	}
+
+	return func(value T) (result U, err error) {
+		trial, retryAt, allowed := breaker.acquire()
+		if !allowed {
+			return result, &CircuitOpenError{Value: value, RetryAt: retryAt}
+		}
+
+		// deferred so that a panic counts as a failure, rather than leaving a trial call in flight forever
+		succeeded := false
+		defer func() {
+			breaker.release(trial, succeeded)
+		}()
+
+		result, err = function(value)
+		succeeded = err == nil
+
+		return result, err
+	}
+}
+
+type circuitBreaker struct {
+	mutex         sync.Mutex
+	config        CircuitBreakerConfig
+	clock         Clock
+	state         CircuitState
+	failures      int
+	openedAt      time.Time
+	trialInFlight bool
+}
+
+// acquire decides whether a call may be made. `trial` is whether the call decides the state of a half-open circuit. `retryAt` is set when the call isn't allowed.
+func (receiver *circuitBreaker) acquire() (trial bool, retryAt time.Time, allowed bool) {
+	receiver.mutex.Lock()
+	defer receiver.mutex.Unlock()
+
+	cooledDownAt := receiver.openedAt.Add(receiver.config.Cooldown)
+	if receiver.state == CircuitOpen && !receiver.clock.Now().Before(cooledDownAt) {
+		receiver.setState(CircuitHalfOpen)
+	}
+
+	switch receiver.state {
+	case CircuitClosed:
+		return false, time.Time{}, true
+	case CircuitHalfOpen:
+		if receiver.trialInFlight {
+			return false, cooledDownAt, false
+		}
+		receiver.trialInFlight = true
+		return true, time.Time{}, true
+	default:
+		return false, cooledDownAt, false
+	}
+}
+
+// release records the outcome of a call allowed by `acquire`.
+func (receiver *circuitBreaker) release(trial bool, succeeded bool) {
+	receiver.mutex.Lock()
+	defer receiver.mutex.Unlock()
+
+	if trial {
+		receiver.trialInFlight = false
+		if succeeded {
+			receiver.failures = 0
+			receiver.setState(CircuitClosed)
+		} else {
+			receiver.open()
+		}
+		return
+	}
+
+	// a call let through before the circuit opened doesn't count once it has
+	if receiver.state != CircuitClosed {
+		return
+	}
+
+	if succeeded {
+		receiver.failures = 0
+		return
+	}
+
+	receiver.failures++
+	if receiver.failures >= max(receiver.config.FailureThreshold, 1) {
+		receiver.open()
+	}
+}
+
+func (receiver *circuitBreaker) open() {
+	receiver.failures = 0
+	receiver.openedAt = receiver.clock.Now()
+	receiver.setState(CircuitOpen)
+}
+
+func (receiver *circuitBreaker) setState(state CircuitState) {
+	previous := receiver.state
+	receiver.state = state
+
+	if receiver.config.OnStateChange != nil && previous != state {
+		receiver.config.OnStateChange(previous, state)
+	}
+}
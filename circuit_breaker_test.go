@@ -0,0 +1,187 @@
+package rangechain
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type circuitTransition struct {
+	from CircuitState
+	to   CircuitState
+}
+
+func newTestCircuitBreaker(clock Clock, transitions *[]circuitTransition) func(string) (int, error) {
+	return WithCircuitBreaker(strconv.Atoi, CircuitBreakerConfig{
+		FailureThreshold: 2,
+		Cooldown:         time.Minute,
+		Clock:            clock,
+		OnStateChange: func(from CircuitState, to CircuitState) {
+			*transitions = append(*transitions, circuitTransition{from: from, to: to})
+		},
+	})
+}
+
+func TestWithCircuitBreakerOpensAfterConsecutiveFailures(t *testing.T) {
+	assert := assert.New(t)
+
+	clock := newFakeClock()
+	var transitions []circuitTransition
+	function := newTestCircuitBreaker(clock, &transitions)
+
+	_, err := function("DogCow")
+	assert.ErrorIs(err, strconv.ErrSyntax)
+	_, err = function("1")
+	assert.Nil(err)
+	_, err = function("DogCow")
+	assert.ErrorIs(err, strconv.ErrSyntax)
+	assert.Empty(transitions)
+
+	_, err = function("Moof!")
+	assert.ErrorIs(err, strconv.ErrSyntax)
+	assert.Equal([]circuitTransition{{from: CircuitClosed, to: CircuitOpen}}, transitions)
+
+	_, err = function("2")
+
+	var openError *CircuitOpenError
+	assert.ErrorAs(err, &openError)
+	assert.Equal("2", openError.Value)
+	assert.Equal(clock.Now().Add(time.Minute), openError.RetryAt)
+}
+
+func TestWithCircuitBreakerClosesAfterSuccessfulTrial(t *testing.T) {
+	assert := assert.New(t)
+
+	clock := newFakeClock()
+	var transitions []circuitTransition
+	function := newTestCircuitBreaker(clock, &transitions)
+
+	_, _ = function("DogCow")
+	_, _ = function("Moof!")
+	clock.Advance(time.Minute)
+
+	value, err := function("3")
+
+	assert.Equal(3, value)
+	assert.Nil(err)
+	assert.Equal([]circuitTransition{
+		{from: CircuitClosed, to: CircuitOpen},
+		{from: CircuitOpen, to: CircuitHalfOpen},
+		{from: CircuitHalfOpen, to: CircuitClosed},
+	}, transitions)
+}
+
+func TestWithCircuitBreakerReopensAfterFailedTrial(t *testing.T) {
+	assert := assert.New(t)
+
+	clock := newFakeClock()
+	var transitions []circuitTransition
+	function := newTestCircuitBreaker(clock, &transitions)
+
+	_, _ = function("DogCow")
+	_, _ = function("Moof!")
+	clock.Advance(time.Minute)
+
+	_, err := function("Clarus")
+	assert.ErrorIs(err, strconv.ErrSyntax)
+
+	_, err = function("4")
+
+	var openError *CircuitOpenError
+	assert.ErrorAs(err, &openError)
+	assert.Equal(clock.Now().Add(time.Minute), openError.RetryAt)
+	assert.Equal([]circuitTransition{
+		{from: CircuitClosed, to: CircuitOpen},
+		{from: CircuitOpen, to: CircuitHalfOpen},
+		{from: CircuitHalfOpen, to: CircuitOpen},
+	}, transitions)
+}
+
+func TestWithCircuitBreakerAllowsOneTrialAtATime(t *testing.T) {
+	assert := assert.New(t)
+
+	clock := newFakeClock()
+	expectedError := errors.New("an example error")
+	trialStarted := make(chan struct{})
+	finishTrial := make(chan struct{})
+	calls := 0
+
+	function := WithCircuitBreaker(func(value int) (int, error) {
+		calls++
+		if calls == 1 {
+			return 0, expectedError
+		}
+		close(trialStarted)
+		<-finishTrial
+		return value, nil
+	}, CircuitBreakerConfig{FailureThreshold: 1, Cooldown: time.Minute, Clock: clock})
+
+	_, err := function(1)
+	assert.Equal(expectedError, err)
+	clock.Advance(time.Minute)
+
+	trialResult := make(chan error)
+	go func() {
+		_, err := function(2)
+		trialResult <- err
+	}()
+	<-trialStarted
+
+	_, err = function(3)
+
+	var openError *CircuitOpenError
+	assert.ErrorAs(err, &openError)
+
+	close(finishTrial)
+	assert.Nil(<-trialResult)
+}
+
+func TestWithCircuitBreakerInMap(t *testing.T) {
+	assert := assert.New(t)
+
+	function := WithCircuitBreaker(strconv.Atoi, CircuitBreakerConfig{FailureThreshold: 2, Cooldown: time.Minute, Clock: newFakeClock()})
+
+	var openErrors int
+	policy := DeadLetter(func(element any, err error) {
+		var openError *CircuitOpenError
+		if errors.As(err, &openError) {
+			openErrors++
+		}
+	})
+
+	actualSlice, err := FromSlice([]string{"1", "DogCow", "Moof!", "4", "5"}).OnError(policy).Map(function).Slice()
+
+	assert.Equal([]int{1}, actualSlice)
+	assert.Nil(err)
+	assert.Equal(2, openErrors)
+}
+
+func TestWithCircuitBreakerCountsPanicAsFailure(t *testing.T) {
+	assert := assert.New(t)
+
+	expectedError := errors.New("an example error")
+	var calls []int
+
+	function := WithCircuitBreaker(func(value int) (int, error) {
+		calls = append(calls, value)
+		if value == 1 {
+			return 0, expectedError
+		} else if value == 2 {
+			panic("Moof!")
+		}
+		return value * 10, nil
+	}, CircuitBreakerConfig{FailureThreshold: 1, Clock: newFakeClock()})
+
+	actualSlice, err := FromSlice([]int{1, 2, 3, 4}).Map(function).Recover().SkipErrors(nil).Slice()
+
+	assert.Equal([]int{30, 40}, actualSlice)
+	assert.Nil(err)
+	assert.Equal([]int{1, 2, 3, 4}, calls)
+}
+
+func TestCircuitStateString(t *testing.T) {
+	assert.Equal(t, "half-open", CircuitHalfOpen.String())
+}
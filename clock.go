@@ -0,0 +1,38 @@
+package rangechain
+
+import "time"
+
+// Clock tells the time and waits for time to pass. Chaining methods that depend on time, like `RateLimit`, read it from the chain so a test can substitute a fake clock with `WithClock`.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+	// After waits for `duration` to pass and then sends the current time on the returned channel.
+	After(duration time.Duration) <-chan time.Time
+}
+
+// WithClock sets the clock used by the chaining methods called after it that depend on time. Without it, they use the system clock.
+func (receiver *Link[T]) WithClock(clock Clock) *Link[T] {
+	link := *receiver
+	link.clock = clock
+
+	return &link
+}
+
+// currentClock is the clock set with `WithClock`, or the system clock if none was set.
+func (receiver *Link[T]) currentClock() Clock {
+	if receiver.clock == nil {
+		return systemClock{}
+	}
+
+	return receiver.clock
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) After(duration time.Duration) <-chan time.Time {
+	return time.After(duration)
+}
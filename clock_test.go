@@ -0,0 +1,93 @@
+package rangechain
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeClock only moves when told to. With `autoAdvance`, `After` moves the clock forward by the duration and fires right away, so nothing ever waits.
+type fakeClock struct {
+	mutex       sync.Mutex
+	now         time.Time
+	autoAdvance bool
+	afterCalls  int
+	waiters     []fakeWaiter
+}
+
+type fakeWaiter struct {
+	deadline time.Time
+	channel  chan time.Time
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Date(1984, time.January, 24, 0, 0, 0, 0, time.UTC)}
+}
+
+func (receiver *fakeClock) Now() time.Time {
+	receiver.mutex.Lock()
+	defer receiver.mutex.Unlock()
+
+	return receiver.now
+}
+
+func (receiver *fakeClock) After(duration time.Duration) <-chan time.Time {
+	receiver.mutex.Lock()
+	defer receiver.mutex.Unlock()
+
+	receiver.afterCalls++
+	channel := make(chan time.Time, 1)
+
+	deadline := receiver.now.Add(duration)
+	if receiver.autoAdvance {
+		receiver.now = deadline
+	}
+
+	if !deadline.After(receiver.now) {
+		channel <- receiver.now
+	} else {
+		receiver.waiters = append(receiver.waiters, fakeWaiter{deadline: deadline, channel: channel})
+	}
+
+	return channel
+}
+
+// Advance moves the clock forward and fires every `After` channel whose duration has passed.
+func (receiver *fakeClock) Advance(duration time.Duration) {
+	receiver.mutex.Lock()
+	defer receiver.mutex.Unlock()
+
+	receiver.now = receiver.now.Add(duration)
+
+	var remaining []fakeWaiter
+	for _, waiter := range receiver.waiters {
+		if waiter.deadline.After(receiver.now) {
+			remaining = append(remaining, waiter)
+		} else {
+			waiter.channel <- receiver.now
+		}
+	}
+	receiver.waiters = remaining
+}
+
+// AfterCalls is how many times `After` has been called.
+func (receiver *fakeClock) AfterCalls() int {
+	receiver.mutex.Lock()
+	defer receiver.mutex.Unlock()
+
+	return receiver.afterCalls
+}
+
+func TestWithClockCarriesThroughChainingMethods(t *testing.T) {
+	clock := newFakeClock()
+
+	link := FromSlice([]int{1, 2, 3}).WithClock(clock).Skip(1).Map(func(value int) (int, error) { return value, nil })
+
+	assert.Same(t, clock, link.currentClock())
+}
+
+func TestWithoutClockUsesSystemClock(t *testing.T) {
+	assert.Equal(t, systemClock{}, FromSlice([]int{1, 2, 3}).currentClock())
+}
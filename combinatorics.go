@@ -0,0 +1,188 @@
+package rangechain
+
+import "github.com/halprin/rangechain/internal/generator"
+
+// Product, Combinations, and Permutations are functions rather than chaining methods because a method of `Link[T]` can't return a `Link[Pair[T, U]]` or a `Link[[]T]`: the compiler rejects a method whose result instantiates `Link` with a type built from `T` as an instantiation cycle.
+
+// Product starts a new chain that pairs every value in `left` with every value in `right`. `left` is streamed; `right` is serialized first so it can be replayed for each value in `left`.
+func Product[T, U any](left *Link[T], right *Link[U]) *Link[Pair[T, U]] {
+	var rightValues []U
+	computedRight := false
+	var currentLeft T
+	rightIndex := 0
+
+	productGenerator := func() (Pair[T, U], error) {
+		if !computedRight {
+			var err error
+			rightValues, err = right.slice()
+			if err != nil {
+				return Pair[T, U]{}, err
+			}
+			computedRight = true
+			rightIndex = len(rightValues)
+		}
+
+		if len(rightValues) == 0 {
+			return Pair[T, U]{}, generator.Exhausted
+		}
+
+		if rightIndex >= len(rightValues) {
+			leftValue, err := left.generator()
+			if err != nil {
+				return Pair[T, U]{}, err
+			}
+
+			currentLeft = leftValue
+			rightIndex = 0
+		}
+
+		rightValue := rightValues[rightIndex]
+		rightIndex++
+
+		return Pair[T, U]{Left: currentLeft, Right: rightValue}, nil
+	}
+
+	return newChainedLink(left, "Product", productGenerator)
+}
+
+// Combinations starts a new chain of every `k` sized combination of the values in `link`, in lexicographic order of their positions. Each combination is a new slice. Expensive because it serializes `link` first, but the combinations themselves are computed lazily.
+func Combinations[T any](link *Link[T], k int) *Link[[]T] {
+	var values []T
+	var indices []int
+	computedValues := false
+	exhausted := false
+
+	combinationsGenerator := func() ([]T, error) {
+		if !computedValues {
+			var err error
+			values, err = link.slice()
+			if err != nil {
+				return nil, err
+			}
+			computedValues = true
+
+			if k < 0 || k > len(values) {
+				exhausted = true
+			} else {
+				indices = make([]int, k)
+				for index := range indices {
+					indices[index] = index
+				}
+			}
+		} else if !exhausted {
+			exhausted = !nextCombination(indices, len(values))
+		}
+
+		if exhausted {
+			return nil, generator.Exhausted
+		}
+
+		return pickIndices(values, indices), nil
+	}
+
+	return newChainedLink(link, "Combinations", combinationsGenerator)
+}
+
+// Permutations starts a new chain of every `k` sized ordered arrangement of the values in `link`, in lexicographic order of their positions. Each permutation is a new slice. Expensive because it serializes `link` first, but the permutations themselves are computed lazily.
+func Permutations[T any](link *Link[T], k int) *Link[[]T] {
+	var values []T
+	var indices []int
+	var used []bool
+	computedValues := false
+	exhausted := false
+
+	permutationsGenerator := func() ([]T, error) {
+		if !computedValues {
+			var err error
+			values, err = link.slice()
+			if err != nil {
+				return nil, err
+			}
+			computedValues = true
+
+			if k < 0 || k > len(values) {
+				exhausted = true
+			} else {
+				indices = make([]int, k)
+				used = make([]bool, len(values))
+				for index := range indices {
+					indices[index] = index
+					used[index] = true
+				}
+			}
+		} else if !exhausted {
+			exhausted = !nextPermutation(indices, used)
+		}
+
+		if exhausted {
+			return nil, generator.Exhausted
+		}
+
+		return pickIndices(values, indices), nil
+	}
+
+	return newChainedLink(link, "Permutations", permutationsGenerator)
+}
+
+// nextCombination advances `indices` to the next combination of `n` positions. Returns false when there are no more combinations.
+func nextCombination(indices []int, n int) bool {
+	k := len(indices)
+
+	position := k - 1
+	for position >= 0 && indices[position] == n-k+position {
+		position--
+	}
+
+	if position < 0 {
+		return false
+	}
+
+	indices[position]++
+	for nextPosition := position + 1; nextPosition < k; nextPosition++ {
+		indices[nextPosition] = indices[nextPosition-1] + 1
+	}
+
+	return true
+}
+
+// nextPermutation advances `indices` to the next arrangement of the positions tracked by `used`. Returns false when there are no more permutations.
+func nextPermutation(indices []int, used []bool) bool {
+	for position := len(indices) - 1; position >= 0; position-- {
+		used[indices[position]] = false
+
+		for candidate := indices[position] + 1; candidate < len(used); candidate++ {
+			if used[candidate] {
+				continue
+			}
+
+			indices[position] = candidate
+			used[candidate] = true
+			fillSmallestUnused(indices[position+1:], used)
+
+			return true
+		}
+	}
+
+	return false
+}
+
+func fillSmallestUnused(indices []int, used []bool) {
+	candidate := 0
+	for position := range indices {
+		for used[candidate] {
+			candidate++
+		}
+
+		indices[position] = candidate
+		used[candidate] = true
+	}
+}
+
+func pickIndices[T any](values []T, indices []int) []T {
+	picked := make([]T, 0, len(indices))
+	for _, index := range indices {
+		picked = append(picked, values[index])
+	}
+
+	return picked
+}
@@ -0,0 +1,115 @@
+package rangechain
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProduct(t *testing.T) {
+	assert := assert.New(t)
+
+	expectedSlice := []Pair[int, string]{
+		{Left: 1, Right: "DogCow"},
+		{Left: 1, Right: "Moof"},
+		{Left: 2, Right: "DogCow"},
+		{Left: 2, Right: "Moof"},
+	}
+
+	actualSlice, err := Product(FromSlice([]int{1, 2}), FromSlice([]string{"DogCow", "Moof"})).Slice()
+
+	assert.Equal(expectedSlice, actualSlice)
+	assert.Nil(err)
+}
+
+func TestProductWithEmptyRight(t *testing.T) {
+	assert := assert.New(t)
+
+	actualSlice, err := Product(FromSlice([]int{1, 2}), FromSlice([]string{})).Slice()
+
+	assert.Equal([]Pair[int, string]{}, actualSlice)
+	assert.Nil(err)
+}
+
+func TestProductHasError(t *testing.T) {
+	assert := assert.New(t)
+
+	expectedError := errors.New("an example error")
+	right := newLink(createGeneratorWithError([]int{1, 2, 3}, 2, expectedError))
+
+	_, err := Product(FromSlice([]int{1, 2}), right).Slice()
+
+	assert.Equal(expectedError, err)
+}
+
+func TestCombinations(t *testing.T) {
+	assert := assert.New(t)
+
+	expectedSlice := [][]int{{1, 2}, {1, 3}, {1, 4}, {2, 3}, {2, 4}, {3, 4}}
+
+	actualSlice, err := Combinations(FromSlice([]int{1, 2, 3, 4}), 2).Slice()
+
+	assert.Equal(expectedSlice, actualSlice)
+	assert.Nil(err)
+}
+
+func TestCombinationsOfZero(t *testing.T) {
+	assert := assert.New(t)
+
+	actualSlice, err := Combinations(FromSlice([]int{1, 2, 3}), 0).Slice()
+
+	assert.Equal([][]int{{}}, actualSlice)
+	assert.Nil(err)
+}
+
+func TestCombinationsLargerThanChain(t *testing.T) {
+	assert := assert.New(t)
+
+	actualSlice, err := Combinations(FromSlice([]int{1, 2, 3}), 4).Slice()
+
+	assert.Equal([][]int{}, actualSlice)
+	assert.Nil(err)
+}
+
+func TestCombinationsWithLimit(t *testing.T) {
+	assert := assert.New(t)
+
+	actualSlice, err := Combinations(FromSlice([]int{1, 2, 3, 4, 5}), 3).Limit(2).Slice()
+
+	assert.Equal([][]int{{1, 2, 3}, {1, 2, 4}}, actualSlice)
+	assert.Nil(err)
+}
+
+func TestPermutations(t *testing.T) {
+	assert := assert.New(t)
+
+	expectedSlice := [][]string{{"a", "b"}, {"a", "c"}, {"b", "a"}, {"b", "c"}, {"c", "a"}, {"c", "b"}}
+
+	actualSlice, err := Permutations(FromSlice([]string{"a", "b", "c"}), 2).Slice()
+
+	assert.Equal(expectedSlice, actualSlice)
+	assert.Nil(err)
+}
+
+func TestPermutationsOfAll(t *testing.T) {
+	assert := assert.New(t)
+
+	expectedSlice := [][]int{{1, 2, 3}, {1, 3, 2}, {2, 1, 3}, {2, 3, 1}, {3, 1, 2}, {3, 2, 1}}
+
+	actualSlice, err := Permutations(FromSlice([]int{1, 2, 3}), 3).Slice()
+
+	assert.Equal(expectedSlice, actualSlice)
+	assert.Nil(err)
+}
+
+func TestPermutationsHasError(t *testing.T) {
+	assert := assert.New(t)
+
+	expectedError := errors.New("an example error")
+	link := newLink(createGeneratorWithError([]int{1, 2, 3}, 2, expectedError))
+
+	_, err := Permutations(link, 2).Slice()
+
+	assert.Equal(expectedError, err)
+}
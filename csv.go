@@ -0,0 +1,359 @@
+package rangechain
+
+import (
+	"encoding"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+
+	"github.com/halprin/rangechain/internal/generator"
+)
+
+// CSVOptions configures how CSV is read. The zero value reads standard comma separated values. The fields mirror those of `csv.Reader`.
+type CSVOptions struct {
+	// Comma is the field delimiter. Zero means ','.
+	Comma rune
+	// Comment, if not zero, starts a line that is ignored.
+	Comment rune
+	// LazyQuotes allows a quote to appear in an unquoted field and a non-doubled quote to appear in a quoted field.
+	LazyQuotes bool
+	// TrimLeadingSpace ignores leading white space in a field.
+	TrimLeadingSpace bool
+}
+
+func (receiver CSVOptions) newReader(reader io.Reader) *csv.Reader {
+	csvReader := csv.NewReader(reader)
+	if receiver.Comma != 0 {
+		csvReader.Comma = receiver.Comma
+	}
+	csvReader.Comment = receiver.Comment
+	csvReader.LazyQuotes = receiver.LazyQuotes
+	csvReader.TrimLeadingSpace = receiver.TrimLeadingSpace
+	csvReader.FieldsPerRecord = -1
+
+	return csvReader
+}
+
+// CSVFieldError is generated when a CSV field can't be converted into the struct field it maps to. `Row` is the one-based line the field starts on, and `Column` is the header of the field.
+type CSVFieldError struct {
+	Row    int
+	Column string
+	Err    error
+}
+
+func (receiver *CSVFieldError) Error() string {
+	return fmt.Sprintf("csv row %d, column %q: %v", receiver.Row, receiver.Column, receiver.Err)
+}
+
+func (receiver *CSVFieldError) Unwrap() error {
+	return receiver.Err
+}
+
+// FromCSV starts the chain with each record read from `reader` as a `[]string`. The header, if there is one, is emitted like any other record. A malformed record generates an error.
+// Chaining and terminating methods can now be called on the result.
+func FromCSV(reader io.Reader, options CSVOptions) *Link[[]string] {
+	return newLink(csvRecordGenerator(options.newReader(reader)))
+}
+
+// FromCSVMaps starts the chain with each record read from `reader` as a map from header to field. The first record is the header and is not emitted. Fields without a header are dropped, and headers without a field are missing from the map.
+// Chaining and terminating methods can now be called on the result.
+func FromCSVMaps(reader io.Reader, options CSVOptions) *Link[map[string]string] {
+	csvReader := options.newReader(reader)
+	recordGenerator := csvRecordGenerator(csvReader)
+	var header []string
+
+	mapGenerator := func() (map[string]string, error) {
+		if header == nil {
+			var err error
+			header, err = recordGenerator()
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		record, err := recordGenerator()
+		if err != nil {
+			return nil, err
+		}
+
+		recordMap := make(map[string]string, len(header))
+		for index, field := range record {
+			if index < len(header) {
+				recordMap[header[index]] = field
+			}
+		}
+
+		return recordMap, nil
+	}
+
+	return newLink(mapGenerator)
+}
+
+// FromCSVInto starts the chain by converting each record read from `reader` into the struct `T`. The first record is the header. A column maps to the exported field whose `csv` tag, or name if untagged, matches the header; a tag of "-" ignores the field. Strings, booleans, integers, floats, and `encoding.TextUnmarshaler` fields are supported. A nil pointer to an embedded struct is allocated when one of its fields has a column. A field that can't be converted generates a `*CSVFieldError`.
+// Chaining and terminating methods can now be called on the result.
+func FromCSVInto[T any](reader io.Reader, options CSVOptions) *Link[T] {
+	csvReader := options.newReader(reader)
+	recordGenerator := csvRecordGenerator(csvReader)
+	var header []string
+	var fieldIndices map[string][]int
+
+	intoGenerator := func() (T, error) {
+		var value T
+
+		if header == nil {
+			var err error
+			fieldIndices, err = csvFieldIndices(reflect.TypeFor[T]())
+			if err != nil {
+				return value, err
+			}
+
+			header, err = recordGenerator()
+			if err != nil {
+				return value, err
+			}
+		}
+
+		record, err := recordGenerator()
+		if err != nil {
+			return value, err
+		}
+
+		structValue := reflect.ValueOf(&value).Elem()
+		for index, field := range record {
+			if index >= len(header) {
+				break
+			}
+
+			fieldIndex, ok := fieldIndices[header[index]]
+			if !ok {
+				continue
+			}
+
+			fieldValue, err := settableField(structValue, fieldIndex)
+			if err == nil {
+				err = setCSVField(fieldValue, field)
+			}
+			if err != nil {
+				row, _ := csvReader.FieldPos(index)
+				return value, &CSVFieldError{Row: row, Column: header[index], Err: err}
+			}
+		}
+
+		return value, nil
+	}
+
+	return newLink(intoGenerator)
+}
+
+// WriteCSV writes each value in the chain as a CSV record to `writer`. A chain of `[]string` is written as is. A chain of structs, or pointers to structs, is first given a header made from the same `csv` tags `FromCSVInto` reads; fields promoted through a nil pointer to an embedded struct are written empty. Stops on the first error, from either the chain or the writing, and returns it.
+func (receiver *Link[T]) WriteCSV(writer io.Writer) error {
+	defer receiver.finished(receiver.currentClock().Now())
+
+	csvWriter := csv.NewWriter(writer)
+	valueType := reflect.TypeFor[T]()
+	isRecord := valueType == reflect.TypeFor[[]string]()
+
+	var columns []csvColumn
+	if !isRecord {
+		var err error
+		columns, err = csvColumns(valueType)
+		if err != nil {
+			return err
+		}
+
+		header := make([]string, 0, len(columns))
+		for _, column := range columns {
+			header = append(header, column.name)
+		}
+
+		err = csvWriter.Write(header)
+		if err != nil {
+			return err
+		}
+	}
+
+	for {
+		currentValue, err := receiver.generator()
+		if err != nil {
+			if errors.Is(err, generator.Exhausted) {
+				break
+			}
+			csvWriter.Flush()
+			return err
+		}
+
+		var record []string
+		if isRecord {
+			record = any(currentValue).([]string)
+		} else {
+			record, err = csvRecordFromStruct(reflect.ValueOf(currentValue), columns)
+			if err != nil {
+				csvWriter.Flush()
+				return err
+			}
+		}
+
+		err = csvWriter.Write(record)
+		if err != nil {
+			return err
+		}
+	}
+
+	csvWriter.Flush()
+	return csvWriter.Error()
+}
+
+func csvRecordGenerator(csvReader *csv.Reader) func() ([]string, error) {
+	return func() ([]string, error) {
+		record, err := csvReader.Read()
+		if errors.Is(err, io.EOF) {
+			return nil, generator.Exhausted
+		}
+
+		return record, err
+	}
+}
+
+type csvColumn struct {
+	name  string
+	index []int
+}
+
+// csvColumns lists the fields of the struct, or pointer to struct, `structType` in the order they are declared, named by their `csv` tag.
+func csvColumns(structType reflect.Type) ([]csvColumn, error) {
+	if structType.Kind() == reflect.Pointer {
+		structType = structType.Elem()
+	}
+	if structType.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("csv: %v is not a struct", structType)
+	}
+
+	var columns []csvColumn
+	for _, field := range reflect.VisibleFields(structType) {
+		if !field.IsExported() || field.Anonymous {
+			continue
+		}
+
+		name := field.Name
+		tag, ok := field.Tag.Lookup("csv")
+		if tag == "-" {
+			continue
+		} else if ok && tag != "" {
+			name = tag
+		}
+
+		columns = append(columns, csvColumn{name: name, index: field.Index})
+	}
+
+	return columns, nil
+}
+
+func csvFieldIndices(structType reflect.Type) (map[string][]int, error) {
+	if structType.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("csv: %v is not a struct", structType)
+	}
+
+	columns, err := csvColumns(structType)
+	if err != nil {
+		return nil, err
+	}
+
+	fieldIndices := make(map[string][]int, len(columns))
+	for _, column := range columns {
+		fieldIndices[column.name] = column.index
+	}
+
+	return fieldIndices, nil
+}
+
+func csvRecordFromStruct(structValue reflect.Value, columns []csvColumn) ([]string, error) {
+	if structValue.Kind() == reflect.Pointer {
+		if structValue.IsNil() {
+			return nil, errors.New("csv: cannot write a nil pointer")
+		}
+		structValue = structValue.Elem()
+	}
+
+	record := make([]string, 0, len(columns))
+	for _, column := range columns {
+		fieldValue, err := structValue.FieldByIndexErr(column.index)
+		if err != nil {
+			// promoted through a nil pointer to an embedded struct, so there is no value
+			record = append(record, "")
+			continue
+		}
+
+		field, err := formatCSVField(fieldValue)
+		if err != nil {
+			return nil, fmt.Errorf("csv column %q: %w", column.name, err)
+		}
+
+		record = append(record, field)
+	}
+
+	return record, nil
+}
+
+func setCSVField(fieldValue reflect.Value, field string) error {
+	if unmarshaler, ok := fieldValue.Addr().Interface().(encoding.TextUnmarshaler); ok {
+		return unmarshaler.UnmarshalText([]byte(field))
+	}
+
+	switch fieldValue.Kind() {
+	case reflect.String:
+		fieldValue.SetString(field)
+	case reflect.Bool:
+		parsed, err := strconv.ParseBool(field)
+		if err != nil {
+			return err
+		}
+		fieldValue.SetBool(parsed)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		parsed, err := strconv.ParseInt(field, 10, fieldValue.Type().Bits())
+		if err != nil {
+			return err
+		}
+		fieldValue.SetInt(parsed)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		parsed, err := strconv.ParseUint(field, 10, fieldValue.Type().Bits())
+		if err != nil {
+			return err
+		}
+		fieldValue.SetUint(parsed)
+	case reflect.Float32, reflect.Float64:
+		parsed, err := strconv.ParseFloat(field, fieldValue.Type().Bits())
+		if err != nil {
+			return err
+		}
+		fieldValue.SetFloat(parsed)
+	default:
+		return fmt.Errorf("unsupported field type %v", fieldValue.Type())
+	}
+
+	return nil
+}
+
+func formatCSVField(fieldValue reflect.Value) (string, error) {
+	if marshaler, ok := fieldValue.Interface().(encoding.TextMarshaler); ok {
+		text, err := marshaler.MarshalText()
+		return string(text), err
+	}
+
+	switch fieldValue.Kind() {
+	case reflect.String:
+		return fieldValue.String(), nil
+	case reflect.Bool:
+		return strconv.FormatBool(fieldValue.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(fieldValue.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(fieldValue.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(fieldValue.Float(), 'g', -1, fieldValue.Type().Bits()), nil
+	default:
+		return "", fmt.Errorf("unsupported field type %v", fieldValue.Type())
+	}
+}
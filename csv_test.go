@@ -0,0 +1,156 @@
+package rangechain
+
+import (
+	"bytes"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testCSVRow struct {
+	Name     string    `csv:"name"`
+	Moofs    int       `csv:"moofs"`
+	Spotted  bool      `csv:"spotted"`
+	Weight   float64   `csv:"weight"`
+	Born     time.Time `csv:"born"`
+	Internal string    `csv:"-"`
+}
+
+const testCSVInput = "name,moofs,spotted,weight,born\n" +
+	"Clarus,3,true,12.5,1987-05-04T00:00:00Z\n" +
+	"DogCow,26,false,7,1984-01-24T00:00:00Z\n"
+
+func TestFromCSV(t *testing.T) {
+	assert := assert.New(t)
+
+	expectedSlice := [][]string{{"name", "moofs"}, {"Clarus", "3"}, {"DogCow", "26"}}
+
+	actualSlice, err := FromCSV(strings.NewReader("name;moofs\nClarus;3\nDogCow;26\n"), CSVOptions{Comma: ';'}).Slice()
+
+	assert.Equal(expectedSlice, actualSlice)
+	assert.Nil(err)
+}
+
+func TestFromCSVMaps(t *testing.T) {
+	assert := assert.New(t)
+
+	expectedSlice := []map[string]string{{"name": "Clarus", "moofs": "3"}, {"name": "DogCow", "moofs": "26"}}
+
+	actualSlice, err := FromCSVMaps(strings.NewReader("name,moofs\nClarus,3\nDogCow,26\n"), CSVOptions{}).Slice()
+
+	assert.Equal(expectedSlice, actualSlice)
+	assert.Nil(err)
+}
+
+func TestFromCSVInto(t *testing.T) {
+	assert := assert.New(t)
+
+	expectedSlice := []testCSVRow{
+		{Name: "Clarus", Moofs: 3, Spotted: true, Weight: 12.5, Born: time.Date(1987, time.May, 4, 0, 0, 0, 0, time.UTC)},
+		{Name: "DogCow", Moofs: 26, Spotted: false, Weight: 7, Born: time.Date(1984, time.January, 24, 0, 0, 0, 0, time.UTC)},
+	}
+
+	actualSlice, err := FromCSVInto[testCSVRow](strings.NewReader(testCSVInput), CSVOptions{}).Slice()
+
+	assert.Equal(expectedSlice, actualSlice)
+	assert.Nil(err)
+}
+
+func TestFromCSVIntoHasConversionError(t *testing.T) {
+	assert := assert.New(t)
+
+	input := "name,moofs\nClarus,3\nDogCow,lots\n"
+
+	actualSlice, err := FromCSVInto[testCSVRow](strings.NewReader(input), CSVOptions{}).Slice()
+
+	assert.Len(actualSlice, 1)
+	var fieldError *CSVFieldError
+	assert.True(errors.As(err, &fieldError))
+	assert.Equal(3, fieldError.Row)
+	assert.Equal("moofs", fieldError.Column)
+	assert.ErrorIs(err, strconv.ErrSyntax)
+}
+
+func TestWriteCSVWithStructs(t *testing.T) {
+	assert := assert.New(t)
+
+	var output bytes.Buffer
+	rows, err := FromCSVInto[testCSVRow](strings.NewReader(testCSVInput), CSVOptions{}).Slice()
+	assert.Nil(err)
+
+	err = FromSlice(rows).WriteCSV(&output)
+
+	assert.Equal(testCSVInput, output.String())
+	assert.Nil(err)
+}
+
+type testCSVInner struct {
+	Moofs int `csv:"moofs"`
+}
+
+type testCSVEmbeddedRow struct {
+	Name string `csv:"name"`
+	*testCSVInner
+}
+
+type TestCSVExportedInner struct {
+	Moofs int `csv:"moofs"`
+}
+
+type testCSVExportedEmbeddedRow struct {
+	Name string `csv:"name"`
+	*TestCSVExportedInner
+}
+
+func TestFromCSVIntoWithEmbeddedPointer(t *testing.T) {
+	assert := assert.New(t)
+
+	actualSlice, err := FromCSVInto[testCSVExportedEmbeddedRow](strings.NewReader("name,moofs\nDogCow,3\n"), CSVOptions{}).Slice()
+
+	assert.Equal([]testCSVExportedEmbeddedRow{{Name: "DogCow", TestCSVExportedInner: &TestCSVExportedInner{Moofs: 3}}}, actualSlice)
+	assert.Nil(err)
+}
+
+func TestFromCSVIntoWithUnexportedEmbeddedPointer(t *testing.T) {
+	assert := assert.New(t)
+
+	_, err := FromCSVInto[testCSVEmbeddedRow](strings.NewReader("name,moofs\nDogCow,3\n"), CSVOptions{}).Slice()
+
+	var fieldError *CSVFieldError
+	assert.ErrorAs(err, &fieldError)
+	assert.Equal("moofs", fieldError.Column)
+}
+
+func TestWriteCSVWithNilEmbeddedPointer(t *testing.T) {
+	assert := assert.New(t)
+
+	var output bytes.Buffer
+
+	err := FromSlice([]testCSVEmbeddedRow{{Name: "DogCow"}, {Name: "Clarus", testCSVInner: &testCSVInner{Moofs: 2}}}).WriteCSV(&output)
+
+	assert.Equal("name,moofs\nDogCow,\nClarus,2\n", output.String())
+	assert.Nil(err)
+}
+
+func TestWriteCSVWithRecords(t *testing.T) {
+	assert := assert.New(t)
+
+	var output bytes.Buffer
+
+	err := FromSlice([][]string{{"DogCow", "Moof, Moof!"}}).WriteCSV(&output)
+
+	assert.Equal("DogCow,\"Moof, Moof!\"\n", output.String())
+	assert.Nil(err)
+}
+
+func TestWriteCSVWithNonStruct(t *testing.T) {
+	var output bytes.Buffer
+
+	err := FromSlice([]int{1, 2}).WriteCSV(&output)
+
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,94 @@
+package rangechain
+
+import (
+	"errors"
+
+	"github.com/halprin/rangechain/internal/generator"
+)
+
+type errorMode int
+
+const (
+	failFast errorMode = iota
+	skipAndContinue
+	collectAll
+)
+
+// ErrorPolicy decides what a chaining method does when the function passed to it returns an error. Use `FailFast`, `SkipAndContinue`, `CollectAll`, or `DeadLetter` to make one, and `OnError` to apply it.
+type ErrorPolicy struct {
+	mode       errorMode
+	deadLetter func(element any, err error)
+}
+
+// FailFast generates the error and stops the chain. This is the policy of a chain that hasn't called `OnError`.
+func FailFast() ErrorPolicy {
+	return ErrorPolicy{mode: failFast}
+}
+
+// SkipAndContinue drops the value whose function failed, along with the error, and moves on to the next value.
+func SkipAndContinue() ErrorPolicy {
+	return ErrorPolicy{mode: skipAndContinue}
+}
+
+// CollectAll drops the value whose function failed, remembers the error, and moves on to the next value. Once the chain ends, every remembered error is generated together, combined with `errors.Join`, so terminating methods return them all.
+func CollectAll() ErrorPolicy {
+	return ErrorPolicy{mode: collectAll}
+}
+
+// DeadLetter passes the value whose function failed and the error to `callback`, then drops the value and moves on to the next value.
+func DeadLetter(callback func(element any, err error)) ErrorPolicy {
+	return ErrorPolicy{mode: skipAndContinue, deadLetter: callback}
+}
+
+// OnError sets how the chaining methods called after it handle an error returned by the function passed to them. This covers `Map`, `Filter`, `Flatten`, and their parallel variants. Errors generated earlier in the chain, like a read error from the source, are not affected.
+func (receiver *Link[T]) OnError(policy ErrorPolicy) *Link[T] {
+	link := *receiver
+	link.errorPolicy = policy
+
+	return &link
+}
+
+// errorHandler applies an ErrorPolicy for a single chaining method.
+type errorHandler struct {
+	policy    ErrorPolicy
+	stage     string
+	collected []error
+}
+
+func newErrorHandler(policy ErrorPolicy, stage string) *errorHandler {
+	return &errorHandler{policy: policy, stage: stage}
+}
+
+// functionFailed is called when the function of the chaining method returns `err` for `element`, the `index`th value it received. The error is wrapped in a `ChainError`. Returns the error the chaining method should generate, or `nil` to drop the element and move on.
+func (receiver *errorHandler) functionFailed(index int, element any, err error) error {
+	err = &ChainError{Stage: receiver.stage, Index: index, Value: element, Err: err}
+
+	switch receiver.policy.mode {
+	case skipAndContinue:
+		if receiver.policy.deadLetter != nil {
+			receiver.policy.deadLetter(element, err)
+		}
+		return nil
+	case collectAll:
+		receiver.collected = append(receiver.collected, err)
+		return nil
+	default:
+		return err
+	}
+}
+
+// upstreamFailed is called when the previous link generates `err`, including `generator.Exhausted`. Returns the error the chaining method should generate, which includes any collected errors.
+func (receiver *errorHandler) upstreamFailed(err error) error {
+	if len(receiver.collected) == 0 {
+		return err
+	}
+
+	collected := receiver.collected
+	receiver.collected = nil
+
+	if !errors.Is(err, generator.Exhausted) {
+		collected = append(collected, err)
+	}
+
+	return errors.Join(collected...)
+}
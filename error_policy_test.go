@@ -0,0 +1,156 @@
+package rangechain
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var errorPolicyInput = []string{"1", "DogCow", "3", "Moof!", "5"}
+
+func TestOnErrorFailFast(t *testing.T) {
+	assert := assert.New(t)
+
+	actualSlice, err := FromSlice(errorPolicyInput).OnError(FailFast()).Map(strconv.Atoi).Slice()
+
+	assert.Equal([]int{1}, actualSlice)
+	assert.ErrorIs(err, strconv.ErrSyntax)
+}
+
+func TestOnErrorSkipAndContinue(t *testing.T) {
+	assert := assert.New(t)
+
+	actualSlice, err := FromSlice(errorPolicyInput).OnError(SkipAndContinue()).Map(strconv.Atoi).Slice()
+
+	assert.Equal([]int{1, 3, 5}, actualSlice)
+	assert.Nil(err)
+}
+
+func TestOnErrorCollectAll(t *testing.T) {
+	assert := assert.New(t)
+
+	actualSlice, err := FromSlice(errorPolicyInput).OnError(CollectAll()).Map(strconv.Atoi).Slice()
+
+	assert.Equal([]int{1, 3, 5}, actualSlice)
+	assert.ErrorIs(err, strconv.ErrSyntax)
+	assert.Len(err.(interface{ Unwrap() []error }).Unwrap(), 2)
+}
+
+func TestOnErrorCollectAllAcrossChainingMethods(t *testing.T) {
+	assert := assert.New(t)
+
+	filterError := errors.New("an example error")
+
+	actualSlice, err := FromSlice(errorPolicyInput).
+		OnError(CollectAll()).
+		Map(strconv.Atoi).
+		Filter(func(value int) (bool, error) {
+			if value == 3 {
+				return false, filterError
+			}
+			return true, nil
+		}).
+		Slice()
+
+	assert.Equal([]int{1, 5}, actualSlice)
+	assert.ErrorIs(err, strconv.ErrSyntax)
+	assert.ErrorIs(err, filterError)
+}
+
+func TestOnErrorDeadLetter(t *testing.T) {
+	assert := assert.New(t)
+
+	var deadLetters []any
+	policy := DeadLetter(func(element any, err error) {
+		deadLetters = append(deadLetters, element)
+	})
+
+	actualSlice, err := FromSlice(errorPolicyInput).OnError(policy).Map(strconv.Atoi).Slice()
+
+	assert.Equal([]int{1, 3, 5}, actualSlice)
+	assert.Nil(err)
+	assert.Equal([]any{"DogCow", "Moof!"}, deadLetters)
+}
+
+func TestOnErrorWithFilter(t *testing.T) {
+	assert := assert.New(t)
+
+	expectedError := errors.New("an example error")
+	filterFunction := func(value int) (bool, error) {
+		if value == 4 {
+			return false, expectedError
+		}
+		return value%2 == 0, nil
+	}
+
+	actualSlice, err := FromSlice([]int{2, 4, 6}).OnError(SkipAndContinue()).Filter(filterFunction).Slice()
+
+	assert.Equal([]int{2, 6}, actualSlice)
+	assert.Nil(err)
+}
+
+func TestOnErrorWithFlatten(t *testing.T) {
+	assert := assert.New(t)
+
+	var deadLetters []any
+	policy := DeadLetter(func(element any, err error) {
+		deadLetters = append(deadLetters, element)
+	})
+
+	actualSlice, err := FromSlice([]any{[]int{1, 2}, "oops", []int{3}}).OnError(policy).Flatten[int]().Slice()
+
+	assert.Equal([]int{1, 2, 3}, actualSlice)
+	assert.Nil(err)
+	assert.Equal([]any{"oops"}, deadLetters)
+}
+
+func TestOnErrorWithMapParallel(t *testing.T) {
+	assert := assert.New(t)
+
+	actualSlice, err := FromSlice(errorPolicyInput).OnError(CollectAll()).MapParallel(strconv.Atoi).Slice()
+
+	assert.Equal([]int{1, 3, 5}, actualSlice)
+	assert.ErrorIs(err, strconv.ErrSyntax)
+}
+
+func TestOnErrorWithFilterParallel(t *testing.T) {
+	assert := assert.New(t)
+
+	var deadLetters []any
+	policy := DeadLetter(func(element any, err error) {
+		deadLetters = append(deadLetters, element)
+	})
+	filterFunction := func(value string) (bool, error) {
+		_, err := strconv.Atoi(value)
+		return true, err
+	}
+
+	actualSlice, err := FromSlice(errorPolicyInput).OnError(policy).FilterParallel(filterFunction).Slice()
+
+	assert.Equal([]string{"1", "3", "5"}, actualSlice)
+	assert.Nil(err)
+	assert.Equal([]any{"DogCow", "Moof!"}, deadLetters)
+}
+
+func TestOnErrorDoesNotSkipUpstreamErrors(t *testing.T) {
+	assert := assert.New(t)
+
+	expectedError := errors.New("an example error")
+	link := newLink(createGeneratorWithError([]int{1, 2, 3}, 2, expectedError))
+
+	actualSlice, err := link.OnError(SkipAndContinue()).Map(func(value int) (int, error) { return value, nil }).Slice()
+
+	assert.Equal([]int{1}, actualSlice)
+	assert.Equal(expectedError, err)
+}
+
+func TestOnErrorCarriesThroughChainingMethods(t *testing.T) {
+	assert := assert.New(t)
+
+	actualSlice, err := FromSlice(errorPolicyInput).OnError(SkipAndContinue()).Skip(1).Limit(3).Map(strconv.Atoi).Slice()
+
+	assert.Equal([]int{3}, actualSlice)
+	assert.Nil(err)
+}
@@ -8,47 +8,61 @@ import (
 	"github.com/halprin/rangechain/internal/generator"
 )
 
-// Flatten iterates each chain value; any value that is itself a slice, channel, iterator, or map is descended into (maps emit `keyvalue.KeyValuer[any, any]` entries). Each emitted inner value is type-asserted to `U`; a mismatch injects an error into the chain at that point.
+// Flatten iterates each chain value; any value that is itself a slice, channel, iterator, or map is descended into (maps emit `keyvalue.KeyValuer[any, any]` entries). Each emitted inner value is type-asserted to `U`; a mismatch injects an error into the chain at that point. How that error is handled depends on `OnError`.
 func (receiver *Link[T]) Flatten[U any]() *Link[U] {
+	handler := newErrorHandler(receiver.errorPolicy, receiver.stageName("Flatten"))
 	var currentGenerator func() (any, error)
+	index := -1
 
 	flattenGenerator := func() (U, error) {
-		var innerValue any
-		var err error
-
-		for innerValue == nil {
-			if currentGenerator == nil {
-				var currentValue T
-				currentValue, err = receiver.generator()
-				if err != nil {
-					var zero U
-					return zero, err
+		for {
+			var innerValue any
+			var err error
+
+			for innerValue == nil {
+				if currentGenerator == nil {
+					var currentValue T
+					currentValue, err = receiver.generator()
+					if err != nil {
+						var zero U
+						return zero, handler.upstreamFailed(err)
+					}
+
+					currentAny := any(currentValue)
+					if isSlice(currentAny) || isArray(currentAny) {
+						currentGenerator = sliceOrArrayAnyGenerator(currentAny)
+					} else if isChannel(currentAny) {
+						currentGenerator = channelAnyGenerator(currentAny)
+					} else if isMap(currentAny) {
+						currentGenerator = mapAnyGenerator(currentAny)
+					} else {
+						innerValue = currentAny
+						break
+					}
 				}
 
-				currentAny := any(currentValue)
-				if isSlice(currentAny) || isArray(currentAny) {
-					currentGenerator = sliceOrArrayAnyGenerator(currentAny)
-				} else if isChannel(currentAny) {
-					currentGenerator = channelAnyGenerator(currentAny)
-				} else if isMap(currentAny) {
-					currentGenerator = mapAnyGenerator(currentAny)
-				} else {
-					innerValue = currentAny
-					break
+				innerValue, err = currentGenerator()
+				if errors.Is(err, generator.Exhausted) {
+					innerValue = nil
+					currentGenerator = nil
 				}
 			}
 
-			innerValue, err = currentGenerator()
-			if errors.Is(err, generator.Exhausted) {
-				innerValue = nil
-				currentGenerator = nil
+			index++
+
+			flattenedValue, err := assertTo[U](innerValue)
+			if err != nil {
+				err = handler.functionFailed(index, innerValue, err)
+				if err == nil {
+					continue
+				}
 			}
-		}
 
-		return assertTo[U](innerValue)
+			return flattenedValue, err
+		}
 	}
 
-	return newLink(flattenGenerator)
+	return newChainedLink(receiver, "Flatten", flattenGenerator)
 }
 
 func assertTo[U any](v any) (U, error) {
@@ -0,0 +1,105 @@
+package rangechain
+
+import (
+	"io/fs"
+	"iter"
+	"path"
+
+	"github.com/halprin/rangechain/internal/generator"
+)
+
+// FileEntry is a file or directory found by `FromFS`. The contents are only read when asked for.
+type FileEntry struct {
+	// Path is the slash-separated path of the entry in the file system, including the walk's root.
+	Path string
+	// DirEntry describes the entry.
+	DirEntry fs.DirEntry
+
+	fsys fs.FS
+}
+
+// Open opens the file for reading. The caller is responsible for closing it.
+func (receiver FileEntry) Open() (fs.File, error) {
+	return receiver.fsys.Open(receiver.Path)
+}
+
+// ReadFile reads the whole file.
+func (receiver FileEntry) ReadFile() ([]byte, error) {
+	return fs.ReadFile(receiver.fsys, receiver.Path)
+}
+
+// FSOptions configures how `FromFSWithOptions` walks a file system. The zero value emits every file and directory.
+type FSOptions struct {
+	// Pattern, if not empty, only emits entries whose base name matches it, using the syntax of `path.Match`. Directories are still walked into.
+	Pattern string
+	// SkipDir, if not nil, is called with each directory. Returning true neither emits the directory nor walks into it.
+	SkipDir func(FileEntry) bool
+	// FilesOnly stops directories from being emitted. They are still walked into.
+	FilesOnly bool
+}
+
+// FromFS starts the chain with every file and directory under `root` in `fsys`, in lexical order, walked with `fs.WalkDir`. `root` itself is included. An error walking the file system is passed down the chain.
+// Chaining and terminating methods can now be called on the result.
+func FromFS(fsys fs.FS, root string) *Link[FileEntry] {
+	return FromFSWithOptions(fsys, root, FSOptions{})
+}
+
+// FromFSWithOptions is like FromFS, but filters the entries with `options`. An invalid `Pattern` generates `path.ErrBadPattern`.
+// Chaining and terminating methods can now be called on the result.
+func FromFSWithOptions(fsys fs.FS, root string, options FSOptions) *Link[FileEntry] {
+	next, stop := iter.Pull2(walkFS(fsys, root, options))
+
+	fsGenerator := func() (FileEntry, error) {
+		entry, err, ok := next()
+		if !ok {
+			stop()
+			return FileEntry{}, generator.Exhausted
+		}
+
+		return entry, err
+	}
+
+	return newLink(fsGenerator)
+}
+
+func walkFS(fsys fs.FS, root string, options FSOptions) iter.Seq2[FileEntry, error] {
+	return func(yield func(FileEntry, error) bool) {
+		if options.Pattern != "" {
+			_, err := path.Match(options.Pattern, "")
+			if err != nil {
+				yield(FileEntry{}, err)
+				return
+			}
+		}
+
+		_ = fs.WalkDir(fsys, root, func(entryPath string, dirEntry fs.DirEntry, err error) error {
+			if err != nil {
+				yield(FileEntry{}, err)
+				return fs.SkipAll
+			}
+
+			entry := FileEntry{Path: entryPath, DirEntry: dirEntry, fsys: fsys}
+
+			if dirEntry.IsDir() {
+				if options.SkipDir != nil && options.SkipDir(entry) {
+					return fs.SkipDir
+				} else if options.FilesOnly {
+					return nil
+				}
+			}
+
+			if options.Pattern != "" {
+				matched, _ := path.Match(options.Pattern, dirEntry.Name())
+				if !matched {
+					return nil
+				}
+			}
+
+			if !yield(entry, nil) {
+				return fs.SkipAll
+			}
+
+			return nil
+		})
+	}
+}
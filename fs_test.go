@@ -0,0 +1,80 @@
+package rangechain
+
+import (
+	"bytes"
+	"io/fs"
+	"path"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testFS = fstest.MapFS{
+	"repo/README.md":            {Data: []byte("# DogCow")},
+	"repo/main.go":              {Data: []byte("package main // Moof!")},
+	"repo/internal/helper.go":   {Data: []byte("package internal")},
+	"repo/vendor/clarus/lib.go": {Data: []byte("package clarus // Moof!")},
+}
+
+func fileEntryPaths(entries []FileEntry) []string {
+	paths := []string{}
+	for _, entry := range entries {
+		paths = append(paths, entry.Path)
+	}
+	return paths
+}
+
+func TestFromFS(t *testing.T) {
+	assert := assert.New(t)
+
+	expectedPaths := []string{"repo", "repo/README.md", "repo/internal", "repo/internal/helper.go", "repo/main.go", "repo/vendor", "repo/vendor/clarus", "repo/vendor/clarus/lib.go"}
+
+	entries, err := FromFS(testFS, "repo").Slice()
+
+	assert.Equal(expectedPaths, fileEntryPaths(entries))
+	assert.Nil(err)
+}
+
+func TestFromFSWithOptions(t *testing.T) {
+	assert := assert.New(t)
+
+	options := FSOptions{
+		Pattern:   "*.go",
+		FilesOnly: true,
+		SkipDir: func(entry FileEntry) bool {
+			return entry.DirEntry.Name() == "vendor"
+		},
+	}
+
+	entries, err := FromFSWithOptions(testFS, ".", options).Slice()
+
+	assert.Equal([]string{"repo/internal/helper.go", "repo/main.go"}, fileEntryPaths(entries))
+	assert.Nil(err)
+}
+
+func TestFromFSWithBadPattern(t *testing.T) {
+	_, err := FromFSWithOptions(testFS, ".", FSOptions{Pattern: "["}).Slice()
+
+	assert.ErrorIs(t, err, path.ErrBadPattern)
+}
+
+func TestFromFSMissingRoot(t *testing.T) {
+	_, err := FromFS(testFS, "DogCow").Slice()
+
+	assert.ErrorIs(t, err, fs.ErrNotExist)
+}
+
+func TestFromFSWithFilterParallelOnContents(t *testing.T) {
+	assert := assert.New(t)
+
+	entries, err := FromFSWithOptions(testFS, ".", FSOptions{FilesOnly: true}).
+		FilterParallel(func(entry FileEntry) (bool, error) {
+			contents, err := entry.ReadFile()
+			return bytes.Contains(contents, []byte("Moof!")), err
+		}).
+		Slice()
+
+	assert.Equal([]string{"repo/main.go", "repo/vendor/clarus/lib.go"}, fileEntryPaths(entries))
+	assert.Nil(err)
+}
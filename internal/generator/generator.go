@@ -5,6 +5,7 @@ import (
 	"errors"
 	"iter"
 	"maps"
+	"time"
 
 	"github.com/halprin/rangechain/keyvalue"
 )
@@ -93,3 +94,98 @@ func FromSeq2[K, V any](seq iter.Seq2[K, V]) func() (keyvalue.KeyValuer[K, V], e
 		}, nil
 	}
 }
+
+// Number is the set of types `Range` can count with.
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr | ~float32 | ~float64
+}
+
+// ErrZeroStep is returned by the range generators when asked to step by zero, which would never reach the end.
+var ErrZeroStep = errors.New("range step must not be zero")
+
+// FromRange creates a generator that counts from `start` towards `end` (exclusive) by `step`. A negative `step` counts down. Counting stops instead of wrapping around when the next value doesn't fit in `N`.
+func FromRange[N Number](start N, end N, step N) func() (N, error) {
+	var index N
+	var previous N
+
+	return func() (N, error) {
+		if step == 0 {
+			return 0, ErrZeroStep
+		}
+
+		value := start + index*step
+		// an integer that passed the limits of its type wraps around to the other side of the previous value
+		wrapped := index != 0 && ((step > 0 && value <= previous) || (step < 0 && value >= previous))
+		if wrapped || (step > 0 && value >= end) || (step < 0 && value <= end) {
+			return 0, Exhausted
+		}
+
+		index++
+		previous = value
+
+		return value, nil
+	}
+}
+
+// Naturals creates a generator that counts up from zero forever.
+func Naturals() func() (int, error) {
+	next := 0
+
+	return func() (int, error) {
+		value := next
+		next++
+
+		return value, nil
+	}
+}
+
+// FromTimeRange creates a generator that steps from `start` towards `end` (exclusive) by `step`. A negative `step` steps backwards in time.
+func FromTimeRange(start time.Time, end time.Time, step time.Duration) func() (time.Time, error) {
+	var index time.Duration
+
+	return func() (time.Time, error) {
+		if step == 0 {
+			return time.Time{}, ErrZeroStep
+		}
+
+		value := start.Add(index * step)
+		if (step > 0 && !value.Before(end)) || (step < 0 && !value.After(end)) {
+			return time.Time{}, Exhausted
+		}
+
+		index++
+
+		return value, nil
+	}
+}
+
+// Iterate creates a generator that starts with `seed` and then repeatedly applies `next` to the previous value, forever.
+func Iterate[T any](seed T, next func(T) T) func() (T, error) {
+	current := seed
+	started := false
+
+	return func() (T, error) {
+		if started {
+			current = next(current)
+		}
+		started = true
+
+		return current, nil
+	}
+}
+
+// Repeat creates a generator that returns `value` `times` times. A negative `times` repeats forever.
+func Repeat[T any](value T, times int) func() (T, error) {
+	count := 0
+
+	return func() (T, error) {
+		if times >= 0 && count >= times {
+			var zero T
+			return zero, Exhausted
+		}
+
+		count++
+
+		return value, nil
+	}
+}
@@ -136,3 +136,61 @@ func createTestChannel(size int) chan int {
 
 	return intChannel
 }
+
+func TestFromRangeCountsDown(t *testing.T) {
+	assert := assert.New(t)
+
+	gen := FromRange(3, 0, -1)
+
+	for _, expected := range []int{3, 2, 1} {
+		actual, err := gen()
+		assert.Equal(expected, actual)
+		assert.NoError(err)
+	}
+
+	_, err := gen()
+	assert.ErrorIs(err, Exhausted)
+}
+
+func TestFromRangeStopsBeforeOverflow(t *testing.T) {
+	assert := assert.New(t)
+
+	unsignedGen := FromRange[uint8](250, 255, 10)
+	actual, err := unsignedGen()
+	assert.Equal(uint8(250), actual)
+	assert.NoError(err)
+	_, err = unsignedGen()
+	assert.ErrorIs(err, Exhausted)
+
+	signedGen := FromRange[int8](100, 127, 20)
+	for _, expected := range []int8{100, 120} {
+		actual, err := signedGen()
+		assert.Equal(expected, actual)
+		assert.NoError(err)
+	}
+	_, err = signedGen()
+	assert.ErrorIs(err, Exhausted)
+}
+
+func TestFromRangeCountingDownStopsBeforeOverflow(t *testing.T) {
+	assert := assert.New(t)
+
+	gen := FromRange[int8](-100, -128, -20)
+
+	for _, expected := range []int8{-100, -120} {
+		actual, err := gen()
+		assert.Equal(expected, actual)
+		assert.NoError(err)
+	}
+
+	_, err := gen()
+	assert.ErrorIs(err, Exhausted)
+}
+
+func TestFromRangeWithZeroStep(t *testing.T) {
+	gen := FromRange(0, 10, 0)
+
+	_, err := gen()
+
+	assert.ErrorIs(t, err, ErrZeroStep)
+}
@@ -0,0 +1,54 @@
+package generator
+
+import (
+	"bufio"
+	"errors"
+	"io"
+)
+
+// FromScanner creates a generator for the tokens of a `bufio.Scanner`. An error reported by `Scanner.Err` is returned once the scanner stops.
+func FromScanner(scanner *bufio.Scanner) func() (string, error) {
+	return func() (string, error) {
+		if !scanner.Scan() {
+			err := scanner.Err()
+			if err != nil {
+				return "", err
+			}
+			return "", Exhausted
+		}
+
+		return scanner.Text(), nil
+	}
+}
+
+// ErrInvalidChunkSize is returned by FromReaderChunks when asked for blocks smaller than one byte, which would never use up the reader.
+var ErrInvalidChunkSize = errors.New("chunk size must be positive")
+
+// FromReaderChunks creates a generator that reads `reader` in blocks of `size` bytes. The last block is shorter if the reader runs out partway. Each block is a new slice.
+func FromReaderChunks(reader io.Reader, size int) func() ([]byte, error) {
+	done := false
+
+	return func() ([]byte, error) {
+		if size < 1 {
+			return nil, ErrInvalidChunkSize
+		}
+
+		if done {
+			return nil, Exhausted
+		}
+
+		chunk := make([]byte, size)
+		readCount, err := io.ReadFull(reader, chunk)
+		if errors.Is(err, io.EOF) {
+			done = true
+			return nil, Exhausted
+		} else if errors.Is(err, io.ErrUnexpectedEOF) {
+			done = true
+			return chunk[:readCount], nil
+		} else if err != nil {
+			return nil, err
+		}
+
+		return chunk, nil
+	}
+}
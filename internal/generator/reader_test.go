@@ -0,0 +1,48 @@
+package generator
+
+import (
+	"bufio"
+	"errors"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromScannerSurfacesError(t *testing.T) {
+	assert := assert.New(t)
+
+	expectedError := errors.New("an example error")
+	scanner := bufio.NewScanner(iotest.ErrReader(expectedError))
+
+	_, err := FromScanner(scanner)()
+
+	assert.ErrorIs(err, expectedError)
+}
+
+func TestFromReaderChunks(t *testing.T) {
+	assert := assert.New(t)
+
+	values, err := collect(FromReaderChunks(strings.NewReader("DogCowMoof!"), 4))
+
+	assert.Equal([][]byte{[]byte("DogC"), []byte("owMo"), []byte("of!")}, values)
+	assert.NoError(err)
+}
+
+func TestFromReaderChunksWithInvalidSize(t *testing.T) {
+	for _, size := range []int{0, -1} {
+		_, err := FromReaderChunks(strings.NewReader("DogCow"), size)()
+
+		assert.ErrorIs(t, err, ErrInvalidChunkSize)
+	}
+}
+
+func TestFromReaderChunksExactMultiple(t *testing.T) {
+	assert := assert.New(t)
+
+	values, err := collect(FromReaderChunks(iotest.OneByteReader(strings.NewReader("DogCow")), 3))
+
+	assert.Equal([][]byte{[]byte("Dog"), []byte("Cow")}, values)
+	assert.NoError(err)
+}
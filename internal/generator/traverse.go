@@ -0,0 +1,158 @@
+package generator
+
+// Unfold creates a generator that repeatedly calls `step` with the current state. `step` returns the value to generate, the next state, and whether a value was produced at all; returning false ends the generator.
+func Unfold[S, T any](seed S, step func(S) (T, S, bool, error)) func() (T, error) {
+	state := seed
+	done := false
+
+	return func() (T, error) {
+		if done {
+			var zero T
+			return zero, Exhausted
+		}
+
+		value, nextState, ok, err := step(state)
+		if err != nil {
+			var zero T
+			return zero, err
+		} else if !ok {
+			done = true
+			var zero T
+			return zero, Exhausted
+		}
+
+		state = nextState
+
+		return value, nil
+	}
+}
+
+// TraversalOrder is the order nodes are visited by `Traverse`.
+type TraversalOrder int
+
+const (
+	// BFS visits a node, then all nodes one level deeper, and so on.
+	BFS TraversalOrder = iota
+	// DFSPre visits a node before its children, going as deep as possible first.
+	DFSPre
+	// DFSPost visits a node after its children, going as deep as possible first.
+	DFSPost
+)
+
+// Traverse creates a generator that walks the graph starting at `root`. `children` is only called for a node once the generator needs to go past it. `discover` is called the first time a node is reached and returns false to skip it; pass `nil` to visit every node.
+func Traverse[T any](root T, children func(T) ([]T, error), order TraversalOrder, discover func(T) bool) func() (T, error) {
+	if discover == nil {
+		discover = func(T) bool { return true }
+	}
+
+	if order == DFSPost {
+		return traversePostOrder(root, children, discover)
+	}
+
+	var pending []T
+	var toExpand []T
+	started := false
+
+	return func() (T, error) {
+		if !started {
+			started = true
+			if discover(root) {
+				pending = append(pending, root)
+			}
+		}
+
+		// breadth first only needs the children once the current level runs out, while depth first needs them right away
+		for len(toExpand) > 0 && (order != BFS || len(pending) == 0) {
+			node := toExpand[0]
+			toExpand = toExpand[1:]
+
+			nodeChildren, err := children(node)
+			if err != nil {
+				var zero T
+				return zero, err
+			}
+
+			var discovered []T
+			for _, child := range nodeChildren {
+				if discover(child) {
+					discovered = append(discovered, child)
+				}
+			}
+
+			if order == BFS {
+				pending = append(pending, discovered...)
+			} else {
+				for index := len(discovered) - 1; index >= 0; index-- {
+					pending = append(pending, discovered[index])
+				}
+			}
+		}
+
+		if len(pending) == 0 {
+			var zero T
+			return zero, Exhausted
+		}
+
+		var node T
+		if order == BFS {
+			node = pending[0]
+			pending = pending[1:]
+		} else {
+			node = pending[len(pending)-1]
+			pending = pending[:len(pending)-1]
+		}
+
+		toExpand = append(toExpand, node)
+
+		return node, nil
+	}
+}
+
+type postOrderFrame[T any] struct {
+	node     T
+	children []T
+	expanded bool
+}
+
+func traversePostOrder[T any](root T, children func(T) ([]T, error), discover func(T) bool) func() (T, error) {
+	var stack []*postOrderFrame[T]
+	started := false
+
+	return func() (T, error) {
+		if !started {
+			started = true
+			if discover(root) {
+				stack = append(stack, &postOrderFrame[T]{node: root})
+			}
+		}
+
+		for len(stack) > 0 {
+			top := stack[len(stack)-1]
+
+			if !top.expanded {
+				nodeChildren, err := children(top.node)
+				if err != nil {
+					var zero T
+					return zero, err
+				}
+
+				top.children = nodeChildren
+				top.expanded = true
+			}
+
+			if len(top.children) == 0 {
+				stack = stack[:len(stack)-1]
+				return top.node, nil
+			}
+
+			child := top.children[0]
+			top.children = top.children[1:]
+			if discover(child) {
+				stack = append(stack, &postOrderFrame[T]{node: child})
+			}
+		}
+
+		var zero T
+		return zero, Exhausted
+	}
+}
@@ -0,0 +1,99 @@
+package generator
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testTree = map[string][]string{
+	"root": {"a", "b"},
+	"a":    {"a1", "a2"},
+	"b":    {"b1"},
+}
+
+func testTreeChildren(node string) ([]string, error) {
+	return testTree[node], nil
+}
+
+func collect[T any](gen func() (T, error)) ([]T, error) {
+	var values []T
+	for {
+		value, err := gen()
+		if errors.Is(err, Exhausted) {
+			return values, nil
+		} else if err != nil {
+			return values, err
+		}
+		values = append(values, value)
+	}
+}
+
+func TestUnfold(t *testing.T) {
+	assert := assert.New(t)
+
+	gen := Unfold(1, func(state int) (int, int, bool, error) {
+		return state * state, state + 1, state <= 3, nil
+	})
+
+	values, err := collect(gen)
+	assert.Equal([]int{1, 4, 9}, values)
+	assert.NoError(err)
+}
+
+func TestTraverseBFS(t *testing.T) {
+	assert := assert.New(t)
+
+	values, err := collect(Traverse("root", testTreeChildren, BFS, nil))
+	assert.Equal([]string{"root", "a", "b", "a1", "a2", "b1"}, values)
+	assert.NoError(err)
+}
+
+func TestTraverseDFSPre(t *testing.T) {
+	assert := assert.New(t)
+
+	values, err := collect(Traverse("root", testTreeChildren, DFSPre, nil))
+	assert.Equal([]string{"root", "a", "a1", "a2", "b", "b1"}, values)
+	assert.NoError(err)
+}
+
+func TestTraverseDFSPost(t *testing.T) {
+	assert := assert.New(t)
+
+	values, err := collect(Traverse("root", testTreeChildren, DFSPost, nil))
+	assert.Equal([]string{"a1", "a2", "a", "b1", "b", "root"}, values)
+	assert.NoError(err)
+}
+
+func TestTraverseDoesNotExpandUnconsumedNodes(t *testing.T) {
+	assert := assert.New(t)
+
+	expanded := 0
+	children := func(node string) ([]string, error) {
+		expanded++
+		return testTree[node], nil
+	}
+
+	gen := Traverse("root", children, BFS, nil)
+	_, err := gen()
+
+	assert.NoError(err)
+	assert.Equal(0, expanded)
+}
+
+func TestTraverseChildrenError(t *testing.T) {
+	assert := assert.New(t)
+
+	expectedError := errors.New("an example error")
+	children := func(node string) ([]string, error) {
+		if node == "a" {
+			return nil, expectedError
+		}
+		return testTree[node], nil
+	}
+
+	values, err := collect(Traverse("root", children, DFSPost, nil))
+	assert.Empty(values)
+	assert.ErrorIs(err, expectedError)
+}
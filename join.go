@@ -0,0 +1,316 @@
+package rangechain
+
+import (
+	"errors"
+
+	"github.com/halprin/rangechain/internal/generator"
+)
+
+// Pair holds two values that were produced together, e.g. by `Join` or `Product`.
+type Pair[L, R any] struct {
+	Left  L
+	Right R
+}
+
+// The joins are functions rather than chaining methods because a method of `Link[T]` can't return a `Link[Pair[T, U]]`: the compiler rejects a method whose result instantiates `Link` with a type built from `T` as an instantiation cycle.
+
+// Join starts a new chain that pairs each value in `left` with every value in `right` that has the same key. The keys are computed with `leftKey` and `rightKey`. Values without a match on the other side are dropped. Expensive because it serializes `right` into a hash table first; `left` is streamed.
+func Join[T, U any, K comparable](left *Link[T], right *Link[U], leftKey func(T) K, rightKey func(U) K) *Link[Pair[T, U]] {
+	var table *joinTable[U, K]
+	var currentLeft T
+	var currentMatches []int
+
+	joinGenerator := func() (Pair[T, U], error) {
+		if table == nil {
+			var err error
+			table, err = newJoinTable(right.generator, rightKey)
+			if err != nil {
+				return Pair[T, U]{}, err
+			}
+		}
+
+		for len(currentMatches) == 0 {
+			leftValue, err := left.generator()
+			if err != nil {
+				return Pair[T, U]{}, err
+			}
+
+			currentLeft = leftValue
+			currentMatches = table.match(leftKey(leftValue))
+		}
+
+		rightValue := table.values[currentMatches[0]]
+		currentMatches = currentMatches[1:]
+
+		return Pair[T, U]{Left: currentLeft, Right: rightValue}, nil
+	}
+
+	return newChainedLink(left, "Join", joinGenerator)
+}
+
+// LeftJoin is like Join, but keeps values in `left` that have no match in `right`. A missing right side is represented by a `nil` `Right`.
+func LeftJoin[T, U any, K comparable](left *Link[T], right *Link[U], leftKey func(T) K, rightKey func(U) K) *Link[Pair[T, *U]] {
+	var table *joinTable[U, K]
+	var currentLeft T
+	var currentMatches []int
+
+	leftJoinGenerator := func() (Pair[T, *U], error) {
+		if table == nil {
+			var err error
+			table, err = newJoinTable(right.generator, rightKey)
+			if err != nil {
+				return Pair[T, *U]{}, err
+			}
+		}
+
+		if len(currentMatches) == 0 {
+			leftValue, err := left.generator()
+			if err != nil {
+				return Pair[T, *U]{}, err
+			}
+
+			currentLeft = leftValue
+			currentMatches = table.match(leftKey(leftValue))
+			if len(currentMatches) == 0 {
+				return Pair[T, *U]{Left: leftValue}, nil
+			}
+		}
+
+		rightValue := table.values[currentMatches[0]]
+		currentMatches = currentMatches[1:]
+
+		return Pair[T, *U]{Left: currentLeft, Right: &rightValue}, nil
+	}
+
+	return newChainedLink(left, "LeftJoin", leftJoinGenerator)
+}
+
+// FullOuterJoin is like Join, but keeps values from both sides that have no match on the other side. A missing side is represented by a `nil` `Left` or `Right`. Unmatched values from `right` are emitted after `left` is exhausted, in the order they appeared in `right`.
+func FullOuterJoin[T, U any, K comparable](left *Link[T], right *Link[U], leftKey func(T) K, rightKey func(U) K) *Link[Pair[*T, *U]] {
+	var table *joinTable[U, K]
+	var currentLeft T
+	var currentMatches []int
+	leftExhausted := false
+	unmatchedIndex := 0
+
+	fullOuterJoinGenerator := func() (Pair[*T, *U], error) {
+		if table == nil {
+			var err error
+			table, err = newJoinTable(right.generator, rightKey)
+			if err != nil {
+				return Pair[*T, *U]{}, err
+			}
+		}
+
+		if len(currentMatches) == 0 && !leftExhausted {
+			leftValue, err := left.generator()
+			if err != nil && !errors.Is(err, generator.Exhausted) {
+				return Pair[*T, *U]{}, err
+			}
+
+			if err == nil {
+				currentLeft = leftValue
+				currentMatches = table.match(leftKey(leftValue))
+				if len(currentMatches) == 0 {
+					return Pair[*T, *U]{Left: &leftValue}, nil
+				}
+			} else {
+				leftExhausted = true
+			}
+		}
+
+		if len(currentMatches) > 0 {
+			matchIndex := currentMatches[0]
+			currentMatches = currentMatches[1:]
+			table.matched[matchIndex] = true
+
+			leftValue := currentLeft
+			rightValue := table.values[matchIndex]
+
+			return Pair[*T, *U]{Left: &leftValue, Right: &rightValue}, nil
+		}
+
+		for ; unmatchedIndex < len(table.values); unmatchedIndex++ {
+			if !table.matched[unmatchedIndex] {
+				rightValue := table.values[unmatchedIndex]
+				unmatchedIndex++
+				return Pair[*T, *U]{Right: &rightValue}, nil
+			}
+		}
+
+		return Pair[*T, *U]{}, generator.Exhausted
+	}
+
+	return newChainedLink(left, "FullOuterJoin", fullOuterJoinGenerator)
+}
+
+// joinTable is the hash table built from the right side of a join. `index` maps a key to the positions in `values` that have that key.
+type joinTable[U any, K comparable] struct {
+	values  []U
+	matched []bool
+	index   map[K][]int
+}
+
+func newJoinTable[U any, K comparable](rightGenerator func() (U, error), rightKey func(U) K) (*joinTable[U, K], error) {
+	table := &joinTable[U, K]{
+		index: map[K][]int{},
+	}
+
+	for {
+		rightValue, err := rightGenerator()
+		if err != nil {
+			if errors.Is(err, generator.Exhausted) {
+				break
+			}
+			return nil, err
+		}
+
+		key := rightKey(rightValue)
+		table.index[key] = append(table.index[key], len(table.values))
+		table.values = append(table.values, rightValue)
+	}
+
+	table.matched = make([]bool, len(table.values))
+
+	return table, nil
+}
+
+func (receiver *joinTable[U, K]) match(key K) []int {
+	return receiver.index[key]
+}
+
+// MergeJoinMode selects which unmatched values `MergeJoin` keeps.
+type MergeJoinMode int
+
+const (
+	// MergeInner drops values without a match on the other side.
+	MergeInner MergeJoinMode = iota
+	// MergeLeft keeps values in `left` without a match in `right`.
+	MergeLeft
+	// MergeFullOuter keeps values from both sides without a match on the other side.
+	MergeFullOuter
+)
+
+// MergeJoin starts a new chain that pairs the values of `left` and `right`, which must both already be sorted by key. `compare` returns a negative number when the left value's key sorts before the right value's key, zero when they are equal, and a positive number otherwise. Both chains are advanced in lockstep, so only the run of `right` values sharing the current key is held in memory. A missing side is represented by a `nil` `Left` or `Right`; which unmatched values are kept depends on `mode`. Like `Join`, it is a function rather than a chaining method because it returns a `Link` of pairs.
+func MergeJoin[T, U any](left *Link[T], right *Link[U], compare func(T, U) int, mode MergeJoinMode) *Link[Pair[*T, *U]] {
+	var currentLeft T
+	haveLeft := false
+	leftResolved := false
+	leftExhausted := false
+
+	var currentRight U
+	haveRight := false
+	rightStarted := false
+
+	var group []U
+	groupIndex := 0
+
+	nextRight := func() error {
+		value, err := right.generator()
+		if err != nil {
+			haveRight = false
+			if errors.Is(err, generator.Exhausted) {
+				return nil
+			}
+			return err
+		}
+
+		currentRight = value
+		haveRight = true
+		return nil
+	}
+
+	mergeJoinGenerator := func() (Pair[*T, *U], error) {
+		if !rightStarted {
+			rightStarted = true
+			err := nextRight()
+			if err != nil {
+				return Pair[*T, *U]{}, err
+			}
+		}
+
+		for !leftExhausted {
+			if !haveLeft {
+				leftValue, err := left.generator()
+				if err != nil {
+					if errors.Is(err, generator.Exhausted) {
+						leftExhausted = true
+						break
+					}
+					return Pair[*T, *U]{}, err
+				}
+
+				currentLeft = leftValue
+				haveLeft = true
+				groupIndex = 0
+
+				if len(group) > 0 && compare(currentLeft, group[0]) == 0 {
+					leftResolved = true
+				} else {
+					group = group[:0]
+				}
+			}
+
+			if !leftResolved {
+				if haveRight && compare(currentLeft, currentRight) > 0 {
+					unmatchedRight := currentRight
+					err := nextRight()
+					if err != nil {
+						return Pair[*T, *U]{}, err
+					}
+
+					if mode == MergeFullOuter {
+						return Pair[*T, *U]{Right: &unmatchedRight}, nil
+					}
+					continue
+				}
+
+				for haveRight && compare(currentLeft, currentRight) == 0 {
+					group = append(group, currentRight)
+					err := nextRight()
+					if err != nil {
+						return Pair[*T, *U]{}, err
+					}
+				}
+
+				leftResolved = true
+
+				if len(group) == 0 {
+					haveLeft = false
+					leftResolved = false
+
+					if mode != MergeInner {
+						leftValue := currentLeft
+						return Pair[*T, *U]{Left: &leftValue}, nil
+					}
+					continue
+				}
+			}
+
+			if groupIndex < len(group) {
+				leftValue := currentLeft
+				rightValue := group[groupIndex]
+				groupIndex++
+
+				return Pair[*T, *U]{Left: &leftValue, Right: &rightValue}, nil
+			}
+
+			haveLeft = false
+			leftResolved = false
+		}
+
+		if mode == MergeFullOuter && haveRight {
+			unmatchedRight := currentRight
+			err := nextRight()
+			if err != nil {
+				return Pair[*T, *U]{}, err
+			}
+
+			return Pair[*T, *U]{Right: &unmatchedRight}, nil
+		}
+
+		return Pair[*T, *U]{}, generator.Exhausted
+	}
+
+	return newChainedLink(left, "MergeJoin", mergeJoinGenerator)
+}
@@ -0,0 +1,228 @@
+package rangechain
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/halprin/rangechain/internal/generator"
+	"github.com/stretchr/testify/assert"
+)
+
+type testOrder struct {
+	id         int
+	customerId int
+}
+
+type testCustomer struct {
+	id   int
+	name string
+}
+
+var testOrders = []testOrder{{id: 1, customerId: 10}, {id: 2, customerId: 20}, {id: 3, customerId: 10}, {id: 4, customerId: 99}}
+var testCustomers = []testCustomer{{id: 10, name: "DogCow"}, {id: 20, name: "Clarus"}, {id: 30, name: "Moof"}}
+
+func orderCustomerId(order testOrder) int {
+	return order.customerId
+}
+
+func customerId(customer testCustomer) int {
+	return customer.id
+}
+
+func TestJoin(t *testing.T) {
+	assert := assert.New(t)
+
+	expectedSlice := []Pair[testOrder, testCustomer]{
+		{Left: testOrders[0], Right: testCustomers[0]},
+		{Left: testOrders[1], Right: testCustomers[1]},
+		{Left: testOrders[2], Right: testCustomers[0]},
+	}
+
+	actualSlice, err := Join(FromSlice(testOrders), FromSlice(testCustomers), orderCustomerId, customerId).Slice()
+
+	assert.Equal(expectedSlice, actualSlice)
+	assert.Nil(err)
+}
+
+func TestJoinWithDuplicateRightKeys(t *testing.T) {
+	assert := assert.New(t)
+
+	leftSlice := []int{1, 2}
+	rightSlice := []string{"a1", "b2", "c1"}
+	expectedSlice := []Pair[int, string]{
+		{Left: 1, Right: "a1"},
+		{Left: 1, Right: "c1"},
+		{Left: 2, Right: "b2"},
+	}
+
+	actualSlice, err := Join(FromSlice(leftSlice), FromSlice(rightSlice), func(value int) byte {
+		return byte('0' + value)
+	}, func(value string) byte {
+		return value[1]
+	}).Slice()
+
+	assert.Equal(expectedSlice, actualSlice)
+	assert.Nil(err)
+}
+
+func TestJoinHasRightError(t *testing.T) {
+	assert := assert.New(t)
+
+	expectedError := errors.New("an example error")
+	right := newLink(createGeneratorWithError([]int{1, 2, 3}, 2, expectedError))
+
+	_, err := Join(FromSlice([]int{1, 2, 3}), right, func(value int) int { return value }, func(value int) int { return value }).Slice()
+
+	assert.Equal(expectedError, err)
+}
+
+func TestJoinHasLeftError(t *testing.T) {
+	assert := assert.New(t)
+
+	expectedError := errors.New("an example error")
+	left := newLink(createGeneratorWithError([]int{1, 2, 3}, 2, expectedError))
+	identity := func(value int) int { return value }
+
+	actualSlice, err := Join(left, FromSlice([]int{1, 2, 3}), identity, identity).Slice()
+
+	assert.Equal([]Pair[int, int]{{Left: 1, Right: 1}}, actualSlice)
+	assert.Equal(expectedError, err)
+}
+
+func TestLeftJoin(t *testing.T) {
+	assert := assert.New(t)
+
+	actualSlice, err := LeftJoin(FromSlice(testOrders), FromSlice(testCustomers), orderCustomerId, customerId).Slice()
+
+	assert.Nil(err)
+	assert.Len(actualSlice, 4)
+	assert.Equal(testOrders[0], actualSlice[0].Left)
+	assert.Equal(&testCustomers[0], actualSlice[0].Right)
+	assert.Equal(testOrders[1], actualSlice[1].Left)
+	assert.Equal(&testCustomers[1], actualSlice[1].Right)
+	assert.Equal(testOrders[2], actualSlice[2].Left)
+	assert.Equal(&testCustomers[0], actualSlice[2].Right)
+	assert.Equal(testOrders[3], actualSlice[3].Left)
+	assert.Nil(actualSlice[3].Right)
+}
+
+func TestFullOuterJoin(t *testing.T) {
+	assert := assert.New(t)
+
+	actualSlice, err := FullOuterJoin(FromSlice(testOrders), FromSlice(testCustomers), orderCustomerId, customerId).Slice()
+
+	assert.Nil(err)
+	assert.Len(actualSlice, 5)
+	assert.Equal(&testOrders[0], actualSlice[0].Left)
+	assert.Equal(&testCustomers[0], actualSlice[0].Right)
+	assert.Equal(&testOrders[1], actualSlice[1].Left)
+	assert.Equal(&testCustomers[1], actualSlice[1].Right)
+	assert.Equal(&testOrders[2], actualSlice[2].Left)
+	assert.Equal(&testCustomers[0], actualSlice[2].Right)
+	assert.Equal(&testOrders[3], actualSlice[3].Left)
+	assert.Nil(actualSlice[3].Right)
+	assert.Nil(actualSlice[4].Left)
+	assert.Equal(&testCustomers[2], actualSlice[4].Right)
+}
+
+func TestFullOuterJoinWithEmptyLeft(t *testing.T) {
+	assert := assert.New(t)
+
+	link := newLink(generator.FromSlice([]testOrder{}))
+
+	actualSlice, err := FullOuterJoin(link, FromSlice(testCustomers), orderCustomerId, customerId).Slice()
+
+	assert.Nil(err)
+	assert.Len(actualSlice, 3)
+	for index, pair := range actualSlice {
+		assert.Nil(pair.Left)
+		assert.Equal(&testCustomers[index], pair.Right)
+	}
+}
+
+func TestMergeJoin(t *testing.T) {
+	assert := assert.New(t)
+
+	leftSlice := []int{1, 2, 2, 4, 6}
+	rightSlice := []int{0, 2, 2, 3, 4, 7}
+	expectedSlice := [][2]any{{2, 2}, {2, 2}, {2, 2}, {2, 2}, {4, 4}}
+
+	actualSlice, err := MergeJoin(FromSlice(leftSlice), FromSlice(rightSlice), compareInts, MergeInner).Slice()
+
+	assert.Equal(expectedSlice, dereferenceMergePairs(actualSlice))
+	assert.Nil(err)
+}
+
+func TestMergeJoinLeft(t *testing.T) {
+	assert := assert.New(t)
+
+	leftSlice := []int{1, 2, 2, 4, 6}
+	rightSlice := []int{0, 2, 3, 4, 7}
+	expectedSlice := [][2]any{{1, nil}, {2, 2}, {2, 2}, {4, 4}, {6, nil}}
+
+	actualSlice, err := MergeJoin(FromSlice(leftSlice), FromSlice(rightSlice), compareInts, MergeLeft).Slice()
+
+	assert.Equal(expectedSlice, dereferenceMergePairs(actualSlice))
+	assert.Nil(err)
+}
+
+func TestMergeJoinFullOuter(t *testing.T) {
+	assert := assert.New(t)
+
+	leftSlice := []int{1, 2, 2, 4, 6}
+	rightSlice := []int{0, 2, 3, 4, 7, 8}
+	expectedSlice := [][2]any{{nil, 0}, {1, nil}, {2, 2}, {2, 2}, {nil, 3}, {4, 4}, {6, nil}, {nil, 7}, {nil, 8}}
+
+	actualSlice, err := MergeJoin(FromSlice(leftSlice), FromSlice(rightSlice), compareInts, MergeFullOuter).Slice()
+
+	assert.Equal(expectedSlice, dereferenceMergePairs(actualSlice))
+	assert.Nil(err)
+}
+
+func TestMergeJoinWithDifferentTypes(t *testing.T) {
+	assert := assert.New(t)
+
+	compare := func(order testOrder, customer testCustomer) int {
+		return order.customerId - customer.id
+	}
+	sortedOrders := []testOrder{testOrders[0], testOrders[2], testOrders[1]}
+
+	actualSlice, err := MergeJoin(FromSlice(sortedOrders), FromSlice(testCustomers), compare, MergeInner).Slice()
+
+	assert.Nil(err)
+	assert.Len(actualSlice, 3)
+	assert.Equal(testCustomers[0], *actualSlice[0].Right)
+	assert.Equal(testCustomers[0], *actualSlice[1].Right)
+	assert.Equal(testCustomers[1], *actualSlice[2].Right)
+}
+
+func TestMergeJoinHasError(t *testing.T) {
+	assert := assert.New(t)
+
+	expectedError := errors.New("an example error")
+	right := newLink(createGeneratorWithError([]int{1, 2, 3}, 3, expectedError))
+
+	actualSlice, err := MergeJoin(FromSlice([]int{1, 2, 3}), right, compareInts, MergeInner).Slice()
+
+	assert.Equal([][2]any{{1, 1}}, dereferenceMergePairs(actualSlice))
+	assert.Equal(expectedError, err)
+}
+
+func compareInts(left int, right int) int {
+	return left - right
+}
+
+func dereferenceMergePairs(pairs []Pair[*int, *int]) [][2]any {
+	dereferenced := [][2]any{}
+	for _, pair := range pairs {
+		var entry [2]any
+		if pair.Left != nil {
+			entry[0] = *pair.Left
+		}
+		if pair.Right != nil {
+			entry[1] = *pair.Right
+		}
+		dereferenced = append(dereferenced, entry)
+	}
+	return dereferenced
+}
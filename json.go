@@ -0,0 +1,144 @@
+package rangechain
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/halprin/rangechain/internal/generator"
+)
+
+// JSONLineError is generated when a line of newline-delimited JSON can't be decoded. `Line` is one-based.
+type JSONLineError struct {
+	Line int
+	Err  error
+}
+
+func (receiver *JSONLineError) Error() string {
+	return fmt.Sprintf("json line %d: %v", receiver.Line, receiver.Err)
+}
+
+func (receiver *JSONLineError) Unwrap() error {
+	return receiver.Err
+}
+
+// FromJSONLines starts the chain by decoding each line of newline-delimited JSON from `reader` into a `T`. Blank lines are ignored. A line that can't be decoded generates a `*JSONLineError`.
+// Chaining and terminating methods can now be called on the result.
+func FromJSONLines[T any](reader io.Reader) *Link[T] {
+	return newLink(jsonLinesGenerator[T](reader, false))
+}
+
+// FromJSONLinesSkipInvalid is like FromJSONLines, but silently drops lines that can't be decoded instead of generating an error. Read errors are still passed down the chain.
+// Chaining and terminating methods can now be called on the result.
+func FromJSONLinesSkipInvalid[T any](reader io.Reader) *Link[T] {
+	return newLink(jsonLinesGenerator[T](reader, true))
+}
+
+func jsonLinesGenerator[T any](reader io.Reader, skipInvalid bool) func() (T, error) {
+	bufferedReader := bufio.NewReader(reader)
+	lineNumber := 0
+	done := false
+
+	return func() (T, error) {
+		var value T
+
+		for !done {
+			line, err := bufferedReader.ReadBytes('\n')
+			if errors.Is(err, io.EOF) {
+				done = true
+			} else if err != nil {
+				return value, err
+			}
+
+			lineNumber++
+
+			line = bytes.TrimSpace(line)
+			if len(line) == 0 {
+				continue
+			}
+
+			err = json.Unmarshal(line, &value)
+			if err != nil {
+				if skipInvalid {
+					var zero T
+					value = zero
+					continue
+				}
+				return value, &JSONLineError{Line: lineNumber, Err: err}
+			}
+
+			return value, nil
+		}
+
+		return value, generator.Exhausted
+	}
+}
+
+// FromJSONArray starts the chain by decoding each element of the top-level JSON array in `reader` into a `T`. The array is streamed, so it is never held in memory all at once. Input that isn't an array, or an element that can't be decoded, generates an error.
+// Chaining and terminating methods can now be called on the result.
+func FromJSONArray[T any](reader io.Reader) *Link[T] {
+	decoder := json.NewDecoder(reader)
+	started := false
+	done := false
+
+	arrayGenerator := func() (T, error) {
+		var value T
+
+		if done {
+			return value, generator.Exhausted
+		}
+
+		if !started {
+			started = true
+
+			token, err := decoder.Token()
+			if err != nil {
+				return value, err
+			} else if token != json.Delim('[') {
+				return value, fmt.Errorf("json array: expected '[' but found %v", token)
+			}
+		}
+
+		if !decoder.More() {
+			done = true
+
+			_, err := decoder.Token()
+			if err != nil {
+				return value, err
+			}
+
+			return value, generator.Exhausted
+		}
+
+		err := decoder.Decode(&value)
+
+		return value, err
+	}
+
+	return newLink(arrayGenerator)
+}
+
+// WriteJSONLines encodes each value in the chain as a line of JSON to `writer`. Stops on the first error, from either the chain or the encoding, and returns it.
+func (receiver *Link[T]) WriteJSONLines(writer io.Writer) error {
+	defer receiver.finished(receiver.currentClock().Now())
+
+	encoder := json.NewEncoder(writer)
+
+	for {
+		currentValue, err := receiver.generator()
+		if err != nil {
+			if errors.Is(err, generator.Exhausted) {
+				return nil
+			}
+			return err
+		}
+
+		err = encoder.Encode(currentValue)
+		if err != nil {
+			return err
+		}
+	}
+}
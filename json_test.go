@@ -0,0 +1,112 @@
+package rangechain
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testDogCow struct {
+	Name  string `json:"name"`
+	Moofs int    `json:"moofs"`
+}
+
+func TestFromJSONLines(t *testing.T) {
+	assert := assert.New(t)
+
+	input := "{\"name\": \"Clarus\", \"moofs\": 3}\n\n{\"name\": \"DogCow\", \"moofs\": 26}"
+	expectedSlice := []testDogCow{{Name: "Clarus", Moofs: 3}, {Name: "DogCow", Moofs: 26}}
+
+	actualSlice, err := FromJSONLines[testDogCow](strings.NewReader(input)).Slice()
+
+	assert.Equal(expectedSlice, actualSlice)
+	assert.Nil(err)
+}
+
+func TestFromJSONLinesHasError(t *testing.T) {
+	assert := assert.New(t)
+
+	input := "{\"name\": \"Clarus\", \"moofs\": 3}\n{\"name\": \n{\"name\": \"DogCow\", \"moofs\": 26}\n"
+
+	actualSlice, err := FromJSONLines[testDogCow](strings.NewReader(input)).Slice()
+
+	assert.Equal([]testDogCow{{Name: "Clarus", Moofs: 3}}, actualSlice)
+	var lineError *JSONLineError
+	assert.True(errors.As(err, &lineError))
+	assert.Equal(2, lineError.Line)
+	var syntaxError *json.SyntaxError
+	assert.True(errors.As(err, &syntaxError))
+}
+
+func TestFromJSONLinesSkipInvalid(t *testing.T) {
+	assert := assert.New(t)
+
+	input := "{\"name\": \"Clarus\", \"moofs\": 3}\n{\"name\": \n{\"name\": \"DogCow\", \"moofs\": 26}\n"
+	expectedSlice := []testDogCow{{Name: "Clarus", Moofs: 3}, {Name: "DogCow", Moofs: 26}}
+
+	actualSlice, err := FromJSONLinesSkipInvalid[testDogCow](strings.NewReader(input)).Slice()
+
+	assert.Equal(expectedSlice, actualSlice)
+	assert.Nil(err)
+}
+
+func TestFromJSONArray(t *testing.T) {
+	assert := assert.New(t)
+
+	input := `[{"name": "Clarus", "moofs": 3}, {"name": "DogCow", "moofs": 26}]`
+	expectedSlice := []testDogCow{{Name: "Clarus", Moofs: 3}, {Name: "DogCow", Moofs: 26}}
+
+	actualSlice, err := FromJSONArray[testDogCow](strings.NewReader(input)).Slice()
+
+	assert.Equal(expectedSlice, actualSlice)
+	assert.Nil(err)
+}
+
+func TestFromJSONArrayNotAnArray(t *testing.T) {
+	assert := assert.New(t)
+
+	_, err := FromJSONArray[testDogCow](strings.NewReader(`{"name": "Clarus"}`)).Slice()
+
+	assert.NotNil(err)
+	assert.Contains(err.Error(), "expected '['")
+}
+
+func TestFromJSONArrayWithLimit(t *testing.T) {
+	assert := assert.New(t)
+
+	input := `[1, 2, 3, "not a number"`
+
+	actualSlice, err := FromJSONArray[int](strings.NewReader(input)).Limit(3).Slice()
+
+	assert.Equal([]int{1, 2, 3}, actualSlice)
+	assert.Nil(err)
+}
+
+func TestWriteJSONLines(t *testing.T) {
+	assert := assert.New(t)
+
+	var output bytes.Buffer
+	inputSlice := []testDogCow{{Name: "Clarus", Moofs: 3}, {Name: "DogCow", Moofs: 26}}
+
+	err := FromSlice(inputSlice).WriteJSONLines(&output)
+
+	assert.Equal("{\"name\":\"Clarus\",\"moofs\":3}\n{\"name\":\"DogCow\",\"moofs\":26}\n", output.String())
+	assert.Nil(err)
+}
+
+func TestWriteJSONLinesHasError(t *testing.T) {
+	assert := assert.New(t)
+
+	var output bytes.Buffer
+	expectedError := errors.New("an example error")
+	link := newLink(createGeneratorWithError([]int{1, 2, 3}, 2, expectedError))
+
+	err := link.WriteJSONLines(&output)
+
+	assert.Equal("1\n", output.String())
+	assert.Equal(expectedError, err)
+}
@@ -2,7 +2,13 @@ package rangechain
 
 // Link is not meant to be initialized directly by external users. Use the `From*` functions.
 type Link[T any] struct {
-	generator func() (T, error)
+	generator   func() (T, error)
+	errorPolicy ErrorPolicy
+	clock       Clock
+	label       string
+	observers   []Observer
+	probe       *stageProbe
+	position    int
 }
 
 func newLink[T any](generator func() (T, error)) *Link[T] {
@@ -10,3 +16,33 @@ func newLink[T any](generator func() (T, error)) *Link[T] {
 		generator: generator,
 	}
 }
+
+// newChainedLink continues the chain of `parent` with the chaining method `methodName`, carrying over the settings applied to it, like the error policy, the clock, and the observers. A label only applies to the chaining method right after it, so it isn't carried over. When the chain is observed, the new link reports the events of its stage.
+func newChainedLink[T, U any](parent *Link[T], methodName string, generator func() (U, error)) *Link[U] {
+	link := &Link[U]{
+		generator:   generator,
+		errorPolicy: parent.errorPolicy,
+		clock:       parent.clock,
+		observers:   parent.observers,
+		position:    parent.position + 1,
+	}
+
+	if len(parent.observers) > 0 {
+		link.probe = newStageProbe(parent.observers, parent.stageName(methodName), link.position, parent.currentClock())
+		if parent.probe != nil {
+			parent.probe.feed(link.probe)
+		}
+		link.generator = observedGenerator(link.probe, generator)
+	}
+
+	return link
+}
+
+// stageName is the name errors and reports use for the chaining method called on this link. It is the label, if one was set, otherwise `methodName`.
+func (receiver *Link[T]) stageName(methodName string) string {
+	if receiver.label != "" {
+		return receiver.label
+	}
+
+	return methodName
+}
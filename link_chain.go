@@ -1,48 +1,71 @@
 package rangechain
 
 import (
+	"errors"
 	"sort"
 
 	"github.com/halprin/rangechain/internal/generator"
 	"github.com/halprin/rangechain/internal/helper"
 )
 
-// Map runs the `mapFunction` parameter against all the values in the chain. In that function, return what you want to change the value into or an optional error.
+// Map runs the `mapFunction` parameter against all the values in the chain. In that function, return what you want to change the value into or an optional error. How an error is handled depends on `OnError`.
 func (receiver *Link[T]) Map[U any](mapFunction func(T) (U, error)) *Link[U] {
+	handler := newErrorHandler(receiver.errorPolicy, receiver.stageName("Map"))
+	index := -1
+
 	mapGenerator := func() (U, error) {
-		valueToMap, err := receiver.generator()
-		if err != nil {
-			var zero U
-			return zero, err
-		}
+		for {
+			valueToMap, err := receiver.generator()
+			if err != nil {
+				var zero U
+				return zero, handler.upstreamFailed(err)
+			}
+			index++
+
+			mappedValue, err := mapFunction(valueToMap)
+			if err != nil {
+				err = handler.functionFailed(index, valueToMap, err)
+				if err == nil {
+					continue
+				}
+			}
 
-		return mapFunction(valueToMap)
+			return mappedValue, err
+		}
 	}
 
-	return newLink(mapGenerator)
+	return newChainedLink(receiver, "Map", mapGenerator)
 }
 
-// Filter runs the `filterFunction` parameter against all the values in the chain. Returning true keeps the value; returning false drops it.
+// Filter runs the `filterFunction` parameter against all the values in the chain. Returning true keeps the value; returning false drops it. How an error is handled depends on `OnError`.
 func (receiver *Link[T]) Filter(filterFunction func(T) (bool, error)) *Link[T] {
+	handler := newErrorHandler(receiver.errorPolicy, receiver.stageName("Filter"))
+	index := -1
+
 	filterGenerator := func() (T, error) {
 		for {
 			valueToFilter, err := receiver.generator()
 			if err != nil {
 				var zero T
-				return zero, err
+				return zero, handler.upstreamFailed(err)
 			}
+			index++
 
 			valueStays, err := filterFunction(valueToFilter)
 
 			if err != nil {
-				return valueToFilter, err
+				err = handler.functionFailed(index, valueToFilter, err)
+				if err != nil {
+					var zero T
+					return zero, err
+				}
 			} else if valueStays {
 				return valueToFilter, nil
 			}
 		}
 	}
 
-	return newLink(filterGenerator)
+	return newChainedLink(receiver, "Filter", filterGenerator)
 }
 
 // Skip skips the next `skipNumber` values in the chain (including any errors already in flight).
@@ -51,7 +74,7 @@ func (receiver *Link[T]) Skip(skipNumber int) *Link[T] {
 		_, _ = receiver.generator()
 	}
 
-	return newLink(receiver.generator)
+	return newChainedLink(receiver, "Skip", receiver.generator)
 }
 
 // Limit stops the chain after `keepSize` values have been emitted.
@@ -75,7 +98,40 @@ func (receiver *Link[T]) Limit(keepSize int) *Link[T] {
 		return currentValue, err
 	}
 
-	return newLink(limitGenerator)
+	return newChainedLink(receiver, "Limit", limitGenerator)
+}
+
+// Cycle replays the chain forever. The values are remembered during the first pass, so the chain must be finite. An empty chain stays empty.
+func (receiver *Link[T]) Cycle() *Link[T] {
+	var seenValues []T
+	firstPassDone := false
+	currentIndex := 0
+
+	cycleGenerator := func() (T, error) {
+		if !firstPassDone {
+			value, err := receiver.generator()
+			if err == nil {
+				seenValues = append(seenValues, value)
+				return value, nil
+			} else if !errors.Is(err, generator.Exhausted) {
+				return value, err
+			}
+
+			firstPassDone = true
+		}
+
+		if len(seenValues) == 0 {
+			var zero T
+			return zero, generator.Exhausted
+		}
+
+		value := seenValues[currentIndex]
+		currentIndex = (currentIndex + 1) % len(seenValues)
+
+		return value, nil
+	}
+
+	return newChainedLink(receiver, "Cycle", cycleGenerator)
 }
 
 // DistinctFunc removes duplicates. Two values whose `keyFunction` returns the same value are considered equal. Use `func(v T) T { return v }` when the values are already comparable.
@@ -98,44 +154,50 @@ func (receiver *Link[T]) DistinctFunc[K comparable](keyFunction func(T) K) *Link
 		}
 	}
 
-	return newLink(distinctGenerator)
+	return newChainedLink(receiver, "DistinctFunc", distinctGenerator)
 }
 
 // Sort sorts the chain using a `Less` function returned by the `returnLessFunction` parameter. The returned function must satisfy the same requirements as the Interface type's `Less` function (https://pkg.go.dev/sort#Interface). See the TestSortingMaps example in example_test.go. Expensive because it serializes the chain first.
 func (receiver *Link[T]) Sort(returnLessFunction func([]T) func(int, int) bool) *Link[T] {
-	serializedSlice, err := receiver.Slice()
-	if err != nil {
-		generation := func() (T, error) {
-			var zero T
-			return zero, err
-		}
-		return newLink(generation)
-	}
-
-	lessFunction := returnLessFunction(serializedSlice)
-	sort.Slice(serializedSlice, lessFunction)
-
-	generation := generator.FromSlice(serializedSlice)
-
-	return newLink(generation)
+	return receiver.serializedLink("Sort", func(serializedSlice []T) {
+		lessFunction := returnLessFunction(serializedSlice)
+		sort.Slice(serializedSlice, lessFunction)
+	})
 }
 
 // Reverse reverses the order of the chain. Expensive because it serializes the chain first.
 func (receiver *Link[T]) Reverse() *Link[T] {
-	serializedSlice, err := receiver.Slice()
+	return receiver.serializedLink("Reverse", func(serializedSlice []T) {
+		for startIndex, endIndex := 0, len(serializedSlice)-1; startIndex <= endIndex; startIndex, endIndex = startIndex+1, endIndex-1 {
+			serializedSlice[startIndex], serializedSlice[endIndex] = serializedSlice[endIndex], serializedSlice[startIndex]
+		}
+	})
+}
+
+// serializedLink continues the chain with the chaining method `methodName`, which needs every value first. The chain is serialized right away and the values rearranged with `arrange`. An error while serializing is generated instead. When the chain is observed, the time this took counts toward the first event of the stage.
+func (receiver *Link[T]) serializedLink(methodName string, arrange func([]T)) *Link[T] {
+	var generation func() (T, error)
+	// the link is made before serializing so that what the stage receives is reported to its observers
+	link := newChainedLink(receiver, methodName, func() (T, error) {
+		return generation()
+	})
+
+	start := receiver.currentClock().Now()
+
+	serializedSlice, err := receiver.slice()
 	if err != nil {
-		generation := func() (T, error) {
+		generation = func() (T, error) {
 			var zero T
 			return zero, err
 		}
-		return newLink(generation)
+	} else {
+		arrange(serializedSlice)
+		generation = generator.FromSlice(serializedSlice)
 	}
 
-	for startIndex, endIndex := 0, len(serializedSlice)-1; startIndex <= endIndex; startIndex, endIndex = startIndex+1, endIndex-1 {
-		serializedSlice[startIndex], serializedSlice[endIndex] = serializedSlice[endIndex], serializedSlice[startIndex]
+	if link.probe != nil {
+		link.probe.addElapsed(receiver.currentClock().Now().Sub(start))
 	}
 
-	generation := generator.FromSlice(serializedSlice)
-
-	return newLink(generation)
+	return link
 }
@@ -0,0 +1,110 @@
+package rangechain
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/halprin/rangechain/internal/generator"
+)
+
+// PanicError is generated by `Recover` in place of a panic. `Value` is what was passed to `panic`.
+type PanicError struct {
+	Value any
+}
+
+func (receiver *PanicError) Error() string {
+	return fmt.Sprintf("panic: %v", receiver.Value)
+}
+
+// Unwrap returns `Value` when it is an error, so `errors.Is` and `errors.As` can see through to it.
+func (receiver *PanicError) Unwrap() error {
+	err, _ := receiver.Value.(error)
+	return err
+}
+
+// Catch runs `catchFunction` against every error generated earlier in the chain. Returning true replaces the error with the returned value; returning false keeps the error.
+func (receiver *Link[T]) Catch(catchFunction func(error) (T, bool)) *Link[T] {
+	catchGenerator := func() (T, error) {
+		value, err := receiver.generator()
+		if err != nil && !errors.Is(err, generator.Exhausted) {
+			fallback, caught := catchFunction(err)
+			if caught {
+				return fallback, nil
+			}
+		}
+
+		return value, err
+	}
+
+	return newChainedLink(receiver, "Catch", catchGenerator)
+}
+
+// MapErr runs `mapErrFunction` against every error generated earlier in the chain and generates the returned error instead. Use it to annotate errors with `fmt.Errorf` and `%w`. If `mapErrFunction` returns nil, the original error is kept; use `SkipErrors` to drop errors.
+func (receiver *Link[T]) MapErr(mapErrFunction func(error) error) *Link[T] {
+	mapErrGenerator := func() (T, error) {
+		value, err := receiver.generator()
+		if err != nil && !errors.Is(err, generator.Exhausted) {
+			mappedErr := mapErrFunction(err)
+			if mappedErr != nil {
+				err = mappedErr
+			}
+		}
+
+		return value, err
+	}
+
+	return newChainedLink(receiver, "MapErr", mapErrGenerator)
+}
+
+// Recover turns a panic earlier in the chain, like one in a function passed to `Map`, into a `*PanicError` generated at that point. Panics in the goroutines of the parallel variants can't be recovered.
+func (receiver *Link[T]) Recover() *Link[T] {
+	recoverGenerator := func() (value T, err error) {
+		defer func() {
+			panicValue := recover()
+			if panicValue != nil {
+				var zero T
+				value, err = zero, &PanicError{Value: panicValue}
+			}
+		}()
+
+		return receiver.generator()
+	}
+
+	return newChainedLink(receiver, "Recover", recoverGenerator)
+}
+
+// SkipErrors drops every error generated earlier in the chain, along with its value, and moves on to the next value. If `skipped` isn't nil, it is incremented for each dropped error. An error that is generated again right after it was dropped, like the one an earlier `Sort` keeps generating, is passed along instead so the chain ends.
+func (receiver *Link[T]) SkipErrors(skipped *int) *Link[T] {
+	var lastErr error
+
+	skipErrorsGenerator := func() (T, error) {
+		for {
+			value, err := receiver.generator()
+			if err == nil || errors.Is(err, generator.Exhausted) {
+				lastErr = nil
+				return value, err
+			}
+
+			if lastErr != nil && sameError(err, lastErr) {
+				return value, err
+			}
+			lastErr = err
+
+			if skipped != nil {
+				*skipped++
+			}
+		}
+	}
+
+	return newChainedLink(receiver, "SkipErrors", skipErrorsGenerator)
+}
+
+// sameError reports whether `err` and `other` are the same error value, without panicking on error types that can't be compared.
+func sameError(err error, other error) bool {
+	if reflect.TypeOf(err) != reflect.TypeOf(other) || !reflect.TypeOf(err).Comparable() {
+		return false
+	}
+
+	return err == other
+}
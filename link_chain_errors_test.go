@@ -0,0 +1,135 @@
+package rangechain
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCatch(t *testing.T) {
+	assert := assert.New(t)
+
+	actualSlice, err := FromSlice([]string{"1", "DogCow", "3"}).
+		Map(strconv.Atoi).
+		Catch(func(err error) (int, bool) {
+			return -1, errors.Is(err, strconv.ErrSyntax)
+		}).
+		Slice()
+
+	assert.Equal([]int{1, -1, 3}, actualSlice)
+	assert.Nil(err)
+}
+
+func TestCatchKeepsUncaughtError(t *testing.T) {
+	assert := assert.New(t)
+
+	expectedError := errors.New("an example error")
+	link := newLink(createGeneratorWithError([]int{1, 2, 3}, 2, expectedError))
+
+	actualSlice, err := link.Catch(func(err error) (int, bool) { return 0, false }).Slice()
+
+	assert.Equal([]int{1}, actualSlice)
+	assert.Equal(expectedError, err)
+}
+
+func TestMapErr(t *testing.T) {
+	assert := assert.New(t)
+
+	expectedError := errors.New("an example error")
+	link := newLink(createGeneratorWithError([]int{1, 2, 3}, 2, expectedError))
+
+	_, err := link.MapErr(func(err error) error { return fmt.Errorf("reading DogCows: %w", err) }).Slice()
+
+	assert.ErrorIs(err, expectedError)
+	assert.Equal("reading DogCows: an example error", err.Error())
+}
+
+func TestMapErrReturningNilKeepsTheError(t *testing.T) {
+	assert := assert.New(t)
+
+	expectedError := errors.New("an example error")
+	link := newLink(createGeneratorWithError([]int{1, 2, 3}, 2, expectedError))
+
+	actualSlice, err := link.MapErr(func(error) error { return nil }).Slice()
+
+	assert.Equal([]int{1}, actualSlice)
+	assert.Equal(expectedError, err)
+}
+
+func TestRecover(t *testing.T) {
+	assert := assert.New(t)
+
+	actualSlice, err := FromSlice([]int{1, 2, 0, 4}).
+		Map(func(value int) (int, error) {
+			if value == 0 {
+				panic("Moof!")
+			}
+			return 12 / value, nil
+		}).
+		Recover().
+		Slice()
+
+	assert.Equal([]int{12, 6}, actualSlice)
+	var panicError *PanicError
+	assert.True(errors.As(err, &panicError))
+	assert.Equal("Moof!", panicError.Value)
+}
+
+func TestRecoverWithSkipErrors(t *testing.T) {
+	assert := assert.New(t)
+
+	skipped := 0
+	actualSlice, err := FromSlice([]int{1, 2, 0, 4}).
+		Map(func(value int) (int, error) {
+			return 12 / value, nil
+		}).
+		Recover().
+		SkipErrors(&skipped).
+		Slice()
+
+	assert.Equal([]int{12, 6, 3}, actualSlice)
+	assert.Nil(err)
+	assert.Equal(1, skipped)
+}
+
+func TestSkipErrors(t *testing.T) {
+	assert := assert.New(t)
+
+	skipped := 0
+	actualSlice, err := FromSlice([]string{"1", "DogCow", "3", "Moof!"}).Map(strconv.Atoi).SkipErrors(&skipped).Slice()
+
+	assert.Equal([]int{1, 3}, actualSlice)
+	assert.Nil(err)
+	assert.Equal(2, skipped)
+}
+
+func TestSkipErrorsPassesAlongARepeatedError(t *testing.T) {
+	assert := assert.New(t)
+
+	expectedError := errors.New("an example error")
+	skipped := 0
+	actualSlice, err := FromSlice([]int{2, 1}).
+		Map(func(value int) (int, error) { return 0, expectedError }).
+		Sort(func(values []int) func(int, int) bool {
+			return func(i int, j int) bool { return values[i] < values[j] }
+		}).
+		SkipErrors(&skipped).
+		Limit(1).
+		Slice()
+
+	assert.Empty(actualSlice)
+	assert.ErrorIs(err, expectedError)
+	assert.Equal(1, skipped)
+}
+
+func TestSkipErrorsWithoutCounting(t *testing.T) {
+	assert := assert.New(t)
+
+	actualSlice, err := FromSlice([]string{"1", "DogCow", "3"}).Map(strconv.Atoi).SkipErrors(nil).Slice()
+
+	assert.Equal([]int{1, 3}, actualSlice)
+	assert.Nil(err)
+}
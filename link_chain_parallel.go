@@ -4,33 +4,49 @@ import "github.com/halprin/rangechain/internal/generator"
 
 // MapParallel is like Map, but invocations run concurrently. There is overhead to running in parallel so benchmark to ensure you benefit from this version.
 func (receiver *Link[T]) MapParallel[U any](mapFunction func(T) (U, error)) *Link[U] {
+	handler := newErrorHandler(receiver.errorPolicy, receiver.stageName("MapParallel"))
 	computedValues := false
+	var valuesToMap []T
 	var mappedReturnValues []chan U
 	var mappedErrorValues []chan error
 	currentIndex := 0
+	// set before the generator first runs, so the invocations can be reported to the link's observers
+	var link *Link[U]
 
 	mapGenerator := func() (U, error) {
 		if !computedValues {
-			mappedReturnValues, mappedErrorValues = mapFunctionAgainstEntireGenerator(receiver.generator, mapFunction)
+			valuesToMap, mappedReturnValues, mappedErrorValues = mapFunctionAgainstEntireGenerator(receiver.generator, observedFunction(link.probe, mapFunction))
 			computedValues = true
 		}
 
-		if currentIndex >= len(mappedReturnValues) {
-			var zero U
-			return zero, generator.Exhausted
-		}
+		for {
+			if currentIndex >= len(mappedReturnValues) {
+				var zero U
+				return zero, handler.upstreamFailed(generator.Exhausted)
+			}
 
-		value := <-mappedReturnValues[currentIndex]
-		err := <-mappedErrorValues[currentIndex]
-		currentIndex++
+			value := <-mappedReturnValues[currentIndex]
+			err := <-mappedErrorValues[currentIndex]
+			currentIndex++
 
-		return value, err
+			if err != nil {
+				err = handler.functionFailed(currentIndex-1, valuesToMap[currentIndex-1], err)
+				if err == nil {
+					continue
+				}
+			}
+
+			return value, err
+		}
 	}
 
-	return newLink(mapGenerator)
+	link = newChainedLink(receiver, "MapParallel", mapGenerator)
+
+	return link
 }
 
-func mapFunctionAgainstEntireGenerator[T, U any](generatorToParallelize func() (T, error), mapFunction func(T) (U, error)) ([]chan U, []chan error) {
+func mapFunctionAgainstEntireGenerator[T, U any](generatorToParallelize func() (T, error), mapFunction func(T) (U, error)) ([]T, []chan U, []chan error) {
+	var valuesToMap []T
 	var mappedReturnValues []chan U
 	var mappedErrorValues []chan error
 
@@ -40,6 +56,8 @@ func mapFunctionAgainstEntireGenerator[T, U any](generatorToParallelize func() (
 			break
 		}
 
+		valuesToMap = append(valuesToMap, valueToMap)
+
 		mappedReturnValue := make(chan U)
 		mappedReturnValues = append(mappedReturnValues, mappedReturnValue)
 		mappedErrorValue := make(chan error)
@@ -48,7 +66,7 @@ func mapFunctionAgainstEntireGenerator[T, U any](generatorToParallelize func() (
 		go pipeReturnAndErrorValueToChannels(mapFunction, valueToMap, mappedReturnValue, mappedErrorValue)
 	}
 
-	return mappedReturnValues, mappedErrorValues
+	return valuesToMap, mappedReturnValues, mappedErrorValues
 }
 
 func pipeReturnAndErrorValueToChannels[T, U any](mapFunction func(T) (U, error), valueToMap T, returnValueChannel chan U, returnErrorChannel chan error) {
@@ -66,21 +84,24 @@ type filterResult[T any] struct {
 
 // FilterParallel is like Filter, but invocations run concurrently. There is overhead to running in parallel so benchmark to ensure you benefit from this version.
 func (receiver *Link[T]) FilterParallel(filterFunction func(T) (bool, error)) *Link[T] {
+	handler := newErrorHandler(receiver.errorPolicy, receiver.stageName("FilterParallel"))
 	computedValues := false
 	var resultChannels []chan filterResult[T]
 	var errorChannels []chan error
 	currentIndex := 0
+	// set before the generator first runs, so the invocations can be reported to the link's observers
+	var link *Link[T]
 
 	filterGenerator := func() (T, error) {
 		if !computedValues {
-			resultChannels, errorChannels = filterFunctionAgainstEntireGenerator(receiver.generator, filterFunction)
+			resultChannels, errorChannels = filterFunctionAgainstEntireGenerator(receiver.generator, observedFunction(link.probe, filterFunction))
 			computedValues = true
 		}
 
 		for {
 			if currentIndex >= len(resultChannels) {
 				var zero T
-				return zero, generator.Exhausted
+				return zero, handler.upstreamFailed(generator.Exhausted)
 			}
 
 			result := <-resultChannels[currentIndex]
@@ -88,14 +109,20 @@ func (receiver *Link[T]) FilterParallel(filterFunction func(T) (bool, error)) *L
 			currentIndex++
 
 			if err != nil {
-				return result.value, err
+				err = handler.functionFailed(currentIndex-1, result.value, err)
+				if err != nil {
+					var zero T
+					return zero, err
+				}
 			} else if result.keep {
 				return result.value, nil
 			}
 		}
 	}
 
-	return newLink(filterGenerator)
+	link = newChainedLink(receiver, "FilterParallel", filterGenerator)
+
+	return link
 }
 
 func filterFunctionAgainstEntireGenerator[T any](generatorToParallelize func() (T, error), filterFunction func(T) (bool, error)) ([]chan filterResult[T], []chan error) {
@@ -49,7 +49,12 @@ func TestMapParallelHasError(t *testing.T) {
 
 	_, err := link.MapParallel(mapFunction).Slice()
 
-	assert.Equal(expectedError, err)
+	assert.ErrorIs(err, expectedError)
+	var chainError *ChainError
+	assert.True(errors.As(err, &chainError))
+	assert.Equal("MapParallel", chainError.Stage)
+	assert.Equal(3, chainError.Index)
+	assert.Equal(errorValue, chainError.Value)
 }
 
 func TestFilterParallel(t *testing.T) {
@@ -88,5 +93,10 @@ func TestFilterParallelHasError(t *testing.T) {
 
 	_, err := link.FilterParallel(filterFunction).Slice()
 
-	assert.Equal(expectedError, err)
+	assert.ErrorIs(err, expectedError)
+	var chainError *ChainError
+	assert.True(errors.As(err, &chainError))
+	assert.Equal("FilterParallel", chainError.Stage)
+	assert.Equal(4, chainError.Index)
+	assert.Equal(errorValue, chainError.Value)
 }
@@ -50,7 +50,12 @@ func TestMapGeneratesError(t *testing.T) {
 
 	_, err := link.Map(mapFunction).Slice()
 
-	assert.Equal(t, expectedError, err)
+	assert.ErrorIs(t, err, expectedError)
+	var chainError *ChainError
+	assert.True(t, errors.As(err, &chainError))
+	assert.Equal(t, "Map", chainError.Stage)
+	assert.Equal(t, 3, chainError.Index)
+	assert.Equal(t, errorValue, chainError.Value)
 }
 
 func TestFilter(t *testing.T) {
@@ -89,7 +94,12 @@ func TestFilterHasError(t *testing.T) {
 
 	_, err := link.Filter(filterFunction).Slice()
 
-	assert.Equal(expectedError, err)
+	assert.ErrorIs(err, expectedError)
+	var chainError *ChainError
+	assert.True(errors.As(err, &chainError))
+	assert.Equal("Filter", chainError.Stage)
+	assert.Equal(4, chainError.Index)
+	assert.Equal(errorValue, chainError.Value)
 }
 
 func TestSkip(t *testing.T) {
@@ -321,6 +331,27 @@ func TestSortHasError(t *testing.T) {
 	assert.Equal(expectedError, err)
 }
 
+func TestSortSerializesWhenCalled(t *testing.T) {
+	assert := assert.New(t)
+
+	pulled := 0
+	link := FromSlice([]int{2, 1}).Tap(func(int) { pulled++ })
+
+	sorted := link.Sort(func(sliceToSort []int) func(int, int) bool {
+		return func(i int, j int) bool {
+			return sliceToSort[i] < sliceToSort[j]
+		}
+	})
+
+	assert.Equal(2, pulled)
+
+	actualSlice, err := sorted.Slice()
+
+	assert.Equal([]int{1, 2}, actualSlice)
+	assert.Nil(err)
+	assert.Equal(2, pulled)
+}
+
 func TestReverse(t *testing.T) {
 	assert := assert.New(t)
 
@@ -349,6 +380,22 @@ func TestReverseHasError(t *testing.T) {
 	assert.Equal(expectedError, err)
 }
 
+func TestReverseSerializesWhenCalled(t *testing.T) {
+	assert := assert.New(t)
+
+	pulled := 0
+	link := FromSlice([]int{1, 2}).Tap(func(int) { pulled++ })
+
+	reversed := link.Reverse()
+
+	assert.Equal(2, pulled)
+
+	actualSlice, err := reversed.Slice()
+
+	assert.Equal([]int{2, 1}, actualSlice)
+	assert.Nil(err)
+}
+
 func createTestIntChannel(intSlice []int) chan int {
 	intChannel := make(chan int)
 
@@ -377,3 +424,40 @@ func createGeneratorWithError(intSlice []int, valueToErrorOn int, errorToReturn
 		return value, err
 	}
 }
+
+func TestCycle(t *testing.T) {
+	assert := assert.New(t)
+
+	generation := generator.FromSlice([]int{1, 2, 3})
+	link := newLink(generation)
+
+	actualSlice, err := link.Cycle().Limit(7).Slice()
+
+	assert.Equal([]int{1, 2, 3, 1, 2, 3, 1}, actualSlice)
+	assert.Nil(err)
+}
+
+func TestCycleEmpty(t *testing.T) {
+	assert := assert.New(t)
+
+	generation := generator.FromSlice([]int{})
+	link := newLink(generation)
+
+	actualSlice, err := link.Cycle().Slice()
+
+	assert.Equal([]int{}, actualSlice)
+	assert.Nil(err)
+}
+
+func TestCycleHasError(t *testing.T) {
+	assert := assert.New(t)
+
+	expectedError := errors.New("this is an example error")
+	generation := createGeneratorWithError([]int{1, 2, 3}, 2, expectedError)
+	link := newLink(generation)
+
+	actualSlice, err := link.Cycle().Limit(7).Slice()
+
+	assert.Equal([]int{1}, actualSlice)
+	assert.Equal(expectedError, err)
+}
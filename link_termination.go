@@ -9,6 +9,13 @@ import (
 
 // Slice serializes the chain into a slice and returns it. Also returns an error if any previous chain method generated one. On error, the slice is filled in until the error was encountered.
 func (receiver *Link[T]) Slice() ([]T, error) {
+	defer receiver.finished(receiver.currentClock().Now())
+
+	return receiver.slice()
+}
+
+// slice is Slice for chaining methods that serialize the chain, which isn't the end of the chain.
+func (receiver *Link[T]) slice() ([]T, error) {
 	endSlice := []T{}
 
 	for {
@@ -31,6 +38,8 @@ func (receiver *Link[T]) Channel() (<-chan T, <-chan error) {
 	errorChannel := make(chan error)
 
 	go func() {
+		defer receiver.finished(receiver.currentClock().Now())
+
 		for {
 			currentValue, err := receiver.generator()
 			if err != nil {
@@ -54,6 +63,8 @@ func (receiver *Link[T]) Channel() (<-chan T, <-chan error) {
 // Iterator returns an `iter.Seq2[T, error]` so the chain can be consumed with `range`. Yields `(value, nil)` for each value; if an upstream error occurs, yields `(zero, err)` once and stops.
 func (receiver *Link[T]) Iterator() iter.Seq2[T, error] {
 	return func(yield func(T, error) bool) {
+		defer receiver.finished(receiver.currentClock().Now())
+
 		for {
 			currentValue, err := receiver.generator()
 			if err != nil {
@@ -74,6 +85,8 @@ func (receiver *Link[T]) Iterator() iter.Seq2[T, error] {
 
 // ForEach runs `forEachFunction` parameter across every value in the chain. Stops on the first error and returns it.
 func (receiver *Link[T]) ForEach(forEachFunction func(T)) error {
+	defer receiver.finished(receiver.currentClock().Now())
+
 	for {
 		currentValue, err := receiver.generator()
 		if err != nil {
@@ -90,6 +103,8 @@ func (receiver *Link[T]) ForEach(forEachFunction func(T)) error {
 
 // ForEachParallel is like ForEach, but invocations run concurrently. There is overhead to running in parallel so benchmark to ensure you benefit from this version.
 func (receiver *Link[T]) ForEachParallel(forEachFunction func(T)) error {
+	defer receiver.finished(receiver.currentClock().Now())
+
 	for {
 		currentValue, err := receiver.generator()
 		if err != nil {
@@ -106,6 +121,8 @@ func (receiver *Link[T]) ForEachParallel(forEachFunction func(T)) error {
 
 // Count returns the number of values in the chain. Counts accurately even when an error occurs partway, and returns that error.
 func (receiver *Link[T]) Count() (int, error) {
+	defer receiver.finished(receiver.currentClock().Now())
+
 	count := 0
 	var firstError error
 	for {
@@ -126,6 +143,8 @@ func (receiver *Link[T]) Count() (int, error) {
 
 // First returns a pointer to the first value. `nil` if the chain is empty. Also returns an error if encountered.
 func (receiver *Link[T]) First() (*T, error) {
+	defer receiver.finished(receiver.currentClock().Now())
+
 	value, err := receiver.generator()
 	if err != nil {
 		if errors.Is(err, generator.Exhausted) {
@@ -140,6 +159,8 @@ func (receiver *Link[T]) First() (*T, error) {
 
 // Last returns a pointer to the last value. `nil` if the chain is empty. Also returns an error if encountered.
 func (receiver *Link[T]) Last() (*T, error) {
+	defer receiver.finished(receiver.currentClock().Now())
+
 	var lastValue *T
 	var lastError error
 
@@ -156,6 +177,8 @@ func (receiver *Link[T]) Last() (*T, error) {
 
 // AllMatch returns true when the `allMatchFunction` parameter returns true for every value, false otherwise. False (with the error) when an error is encountered or if `allMatchFunction` errors itself.
 func (receiver *Link[T]) AllMatch(allMatchFunction func(T) (bool, error)) (bool, error) {
+	defer receiver.finished(receiver.currentClock().Now())
+
 	for {
 		currentValue, err := receiver.generator()
 		if err != nil {
@@ -177,6 +200,8 @@ func (receiver *Link[T]) AllMatch(allMatchFunction func(T) (bool, error)) (bool,
 
 // AnyMatch returns true when the `anyMatchFunction` parameter returns true for any value, false otherwise. False (with the error) when an error is encountered or if `anyMatchFunction` errors itself.
 func (receiver *Link[T]) AnyMatch(anyMatchFunction func(T) (bool, error)) (bool, error) {
+	defer receiver.finished(receiver.currentClock().Now())
+
 	for {
 		currentValue, err := receiver.generator()
 		if err != nil {
@@ -204,6 +229,8 @@ func (receiver *Link[T]) NoneMatch(noneMatchFunction func(T) (bool, error)) (boo
 
 // Reduce runs the `reduceFunction` parameter to two values in the chain cumulatively. Subsequent calls to `reduceFunction` uses the previous return value from `reduceFunction` as the first argument and the next value in the chain as the second argument. A pointer to the final value is returned. If the chain is empty, `nil` is returned. Also returns an error if any previous chain method generated an error or if an error is returned from the `reduceFunction` function.
 func (receiver *Link[T]) Reduce(reduceFunction func(T, T) (T, error)) (*T, error) {
+	defer receiver.finished(receiver.currentClock().Now())
+
 	nextItem, err := receiver.generator()
 	if err != nil {
 		if errors.Is(err, generator.Exhausted) {
@@ -240,6 +267,8 @@ func (receiver *Link[T]) Reduce(reduceFunction func(T, T) (T, error)) (*T, error
 
 // ReduceWithInitialValue is similar to Reduce, but starts with `initialValue` in the chain.
 func (receiver *Link[T]) ReduceWithInitialValue[A any](reduceFunction func(A, T) (A, error), initialValue A) (A, error) {
+	defer receiver.finished(receiver.currentClock().Now())
+
 	nextItem, err := receiver.generator()
 	if err != nil {
 		if errors.Is(err, generator.Exhausted) {
@@ -0,0 +1,257 @@
+package rangechain
+
+import (
+	"cmp"
+	"errors"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/halprin/rangechain/internal/generator"
+)
+
+// StageEventKind is what happened in a `StageEvent`.
+type StageEventKind int
+
+const (
+	// ElementIn is a stage receiving a value from earlier in the chain.
+	ElementIn StageEventKind = iota
+	// ElementOut is a stage generating a value.
+	ElementOut
+	// StageError is a stage generating an error. An error a stage only passes along from earlier in the chain isn't reported again.
+	StageError
+	// StageExhausted is a stage running out of values.
+	StageExhausted
+	// ChainFinished is a terminating method finishing.
+	ChainFinished
+	// ParallelCall is a parallel stage, like `MapParallel`, finishing a call to its function in a goroutine of its own.
+	ParallelCall
+)
+
+func (receiver StageEventKind) String() string {
+	switch receiver {
+	case ElementIn:
+		return "in"
+	case ElementOut:
+		return "out"
+	case StageError:
+		return "error"
+	case StageExhausted:
+		return "exhausted"
+	case ChainFinished:
+		return "finished"
+	case ParallelCall:
+		return "parallel call"
+	default:
+		return "unknown"
+	}
+}
+
+// StageEvent is something that happened in one stage of an observed chain. A stage is one chaining method.
+type StageEvent struct {
+	Kind StageEventKind
+	// Stage is the name of the chaining method, like "Map" or "Filter", or the label set with `Label`. It is empty for `ChainFinished`.
+	Stage string
+	// Position is where the stage is in the chain, counting chaining methods from 1. It tells apart stages with the same name. For `ChainFinished`, it is the position of the last stage.
+	Position int
+	// Value is the value received or generated, for `ElementIn` and `ElementOut`.
+	Value any
+	// Err is the error generated, for `StageError`.
+	Err error
+	// Duration is how long the event took. For `ElementIn`, it is the time spent waiting on earlier in the chain for the value. For `ElementOut`, `StageError`, and `StageExhausted`, it is the time the stage took to generate it, including that wait. For `ChainFinished`, it is the time the terminating method took. For `ParallelCall`, it is the time the call took.
+	Duration time.Duration
+}
+
+// Observer receives the events of the stages of a chain it was attached to with `Observe`. Stages that run in the background, like `Debounce`, can report events from other goroutines, so `Observe` must be safe for concurrent use.
+type Observer interface {
+	Observe(event StageEvent)
+}
+
+// ObserverFunc adapts a function into an `Observer`.
+type ObserverFunc func(event StageEvent)
+
+func (receiver ObserverFunc) Observe(event StageEvent) {
+	receiver(event)
+}
+
+// Observe attaches `observer` to the chaining methods called after it, which report to it what happens in their stage. More than one observer can be attached. The terminating method reports `ChainFinished` when it is done.
+func (receiver *Link[T]) Observe(observer Observer) *Link[T] {
+	link := *receiver
+	link.observers = append(slices.Clip(receiver.observers), observer)
+
+	// the first stage after this one learns what it receives from this probe, which doesn't report anything itself
+	link.probe = newStageProbe(link.observers, "", receiver.position, receiver.currentClock())
+	link.probe.silent = true
+	link.generator = observedGenerator(link.probe, receiver.generator)
+
+	return &link
+}
+
+// Tap runs `tapFunction` against every value in the chain as it passes, leaving the chain unchanged.
+func (receiver *Link[T]) Tap(tapFunction func(T)) *Link[T] {
+	tapGenerator := func() (T, error) {
+		value, err := receiver.generator()
+		if err == nil {
+			tapFunction(value)
+		}
+
+		return value, err
+	}
+
+	return newChainedLink(receiver, "Tap", tapGenerator)
+}
+
+// TapErr runs `tapFunction` against every error generated earlier in the chain as it passes, leaving the chain unchanged. The end of the chain isn't an error.
+func (receiver *Link[T]) TapErr(tapFunction func(error)) *Link[T] {
+	tapErrGenerator := func() (T, error) {
+		value, err := receiver.generator()
+		if err != nil && !errors.Is(err, generator.Exhausted) {
+			tapFunction(err)
+		}
+
+		return value, err
+	}
+
+	return newChainedLink(receiver, "TapErr", tapErrGenerator)
+}
+
+// finished reports `ChainFinished` to the observers of the chain. Terminating methods defer it with the time they started.
+func (receiver *Link[T]) finished(start time.Time) {
+	if len(receiver.observers) == 0 {
+		return
+	}
+
+	event := StageEvent{
+		Kind:     ChainFinished,
+		Position: receiver.position,
+		Duration: receiver.currentClock().Now().Sub(start),
+	}
+	for _, observer := range receiver.observers {
+		observer.Observe(event)
+	}
+}
+
+// stageProbe reports the events of one stage. It also tells the stage after it, the one fed by this stage, what that stage receives.
+type stageProbe struct {
+	observers []Observer
+	stage     string
+	position  int
+	clock     Clock
+	// silent probes only feed the next stage
+	silent bool
+
+	mutex       sync.Mutex
+	downstream  *stageProbe
+	upstreamErr error
+	// elapsed outside of generating, like serializing when the stage is made, and not yet reported
+	unreported time.Duration
+}
+
+func newStageProbe(observers []Observer, stage string, position int, clock Clock) *stageProbe {
+	return &stageProbe{
+		observers: observers,
+		stage:     stage,
+		position:  position,
+		clock:     clock,
+	}
+}
+
+// addElapsed counts time the stage spent outside of generating toward its next event.
+func (receiver *stageProbe) addElapsed(duration time.Duration) {
+	receiver.mutex.Lock()
+	defer receiver.mutex.Unlock()
+
+	receiver.unreported += duration
+}
+
+// feed makes `downstream` the stage that receives what this stage generates.
+func (receiver *stageProbe) feed(downstream *stageProbe) {
+	receiver.mutex.Lock()
+	defer receiver.mutex.Unlock()
+
+	receiver.downstream = downstream
+}
+
+func observedGenerator[T any](probe *stageProbe, generatorToObserve func() (T, error)) func() (T, error) {
+	return func() (T, error) {
+		start := probe.clock.Now()
+		value, err := generatorToObserve()
+		probe.generated(value, err, probe.clock.Now().Sub(start))
+
+		return value, err
+	}
+}
+
+// observedFunction wraps a function that a parallel stage calls in goroutines so that each call is reported as `ParallelCall`. A `nil` probe leaves the function as is.
+func observedFunction[T, U any](probe *stageProbe, function func(T) (U, error)) func(T) (U, error) {
+	if probe == nil {
+		return function
+	}
+
+	return func(value T) (U, error) {
+		start := probe.clock.Now()
+		result, err := function(value)
+		probe.report(StageEvent{Kind: ParallelCall, Value: value, Duration: probe.clock.Now().Sub(start)})
+
+		return result, err
+	}
+}
+
+func (receiver *stageProbe) generated(value any, err error, duration time.Duration) {
+	receiver.mutex.Lock()
+	downstream := receiver.downstream
+	upstreamErr := receiver.upstreamErr
+	duration += receiver.unreported
+	receiver.unreported = 0
+	receiver.mutex.Unlock()
+
+	switch {
+	case err == nil:
+		receiver.report(StageEvent{Kind: ElementOut, Value: value, Duration: duration})
+		if downstream != nil {
+			downstream.notify(StageEvent{Kind: ElementIn, Stage: downstream.stage, Position: downstream.position, Value: value, Duration: duration})
+		}
+	case errors.Is(err, generator.Exhausted):
+		receiver.report(StageEvent{Kind: StageExhausted, Duration: duration})
+	default:
+		if upstreamErr == nil || !errors.Is(err, upstreamErr) {
+			receiver.report(StageEvent{Kind: StageError, Err: err, Duration: duration})
+		}
+		if downstream != nil {
+			downstream.mutex.Lock()
+			downstream.upstreamErr = err
+			downstream.mutex.Unlock()
+		}
+	}
+}
+
+// report notifies the observers of an event of this stage, unless the probe is silent.
+func (receiver *stageProbe) report(event StageEvent) {
+	if receiver.silent {
+		return
+	}
+
+	event.Stage = receiver.stage
+	event.Position = receiver.position
+	receiver.notify(event)
+}
+
+func (receiver *stageProbe) notify(event StageEvent) {
+	for _, observer := range receiver.observers {
+		observer.Observe(event)
+	}
+}
+
+// stageKey tells apart the stages of a chain for observers that tally them.
+type stageKey struct {
+	position int
+	stage    string
+}
+
+// sortedStageKeys returns the keys of `stages` in chain order.
+func sortedStageKeys[V any](stages map[stageKey]V) []stageKey {
+	return slices.SortedFunc(maps.Keys(stages), func(a stageKey, b stageKey) int {
+		return cmp.Or(cmp.Compare(a.position, b.position), cmp.Compare(a.stage, b.stage))
+	})
+}
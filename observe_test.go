@@ -0,0 +1,173 @@
+package rangechain
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func recordEvents(events *[]StageEvent) Observer {
+	return ObserverFunc(func(event StageEvent) {
+		*events = append(*events, event)
+	})
+}
+
+func TestTap(t *testing.T) {
+	assert := assert.New(t)
+
+	var tapped []int
+	actualSlice, err := FromSlice([]int{1, 2, 3}).Tap(func(value int) { tapped = append(tapped, value) }).Slice()
+
+	assert.Equal([]int{1, 2, 3}, actualSlice)
+	assert.Nil(err)
+	assert.Equal([]int{1, 2, 3}, tapped)
+}
+
+func TestTapErr(t *testing.T) {
+	assert := assert.New(t)
+
+	var tapped []error
+	actualSlice, err := FromSlice([]string{"1", "DogCow"}).Map(strconv.Atoi).TapErr(func(err error) { tapped = append(tapped, err) }).Slice()
+
+	assert.Equal([]int{1}, actualSlice)
+	assert.ErrorIs(err, strconv.ErrSyntax)
+	assert.Len(tapped, 1)
+	assert.Equal(err, tapped[0])
+}
+
+func TestObserve(t *testing.T) {
+	assert := assert.New(t)
+
+	var events []StageEvent
+	actualSlice, err := FromSlice([]int{1, 2, 3}).
+		WithClock(newFakeClock()).
+		Observe(recordEvents(&events)).
+		Map(func(value int) (int, error) { return value * 2, nil }).
+		Filter(func(value int) (bool, error) { return value > 2, nil }).
+		Slice()
+
+	assert.Equal([]int{4, 6}, actualSlice)
+	assert.Nil(err)
+	assert.Equal([]StageEvent{
+		{Kind: ElementIn, Stage: "Map", Position: 1, Value: 1},
+		{Kind: ElementOut, Stage: "Map", Position: 1, Value: 2},
+		{Kind: ElementIn, Stage: "Filter", Position: 2, Value: 2},
+		{Kind: ElementIn, Stage: "Map", Position: 1, Value: 2},
+		{Kind: ElementOut, Stage: "Map", Position: 1, Value: 4},
+		{Kind: ElementIn, Stage: "Filter", Position: 2, Value: 4},
+		{Kind: ElementOut, Stage: "Filter", Position: 2, Value: 4},
+		{Kind: ElementIn, Stage: "Map", Position: 1, Value: 3},
+		{Kind: ElementOut, Stage: "Map", Position: 1, Value: 6},
+		{Kind: ElementIn, Stage: "Filter", Position: 2, Value: 6},
+		{Kind: ElementOut, Stage: "Filter", Position: 2, Value: 6},
+		{Kind: StageExhausted, Stage: "Map", Position: 1},
+		{Kind: StageExhausted, Stage: "Filter", Position: 2},
+		{Kind: ChainFinished, Position: 2},
+	}, events)
+}
+
+func TestObserveReportsErrorWhereItHappens(t *testing.T) {
+	assert := assert.New(t)
+
+	var events []StageEvent
+	_, err := FromSlice([]string{"DogCow"}).
+		Observe(recordEvents(&events)).
+		Label("parse").
+		Map(strconv.Atoi).
+		Filter(func(value int) (bool, error) { return true, nil }).
+		Slice()
+
+	var stageErrors []StageEvent
+	for _, event := range events {
+		if event.Kind == StageError {
+			stageErrors = append(stageErrors, event)
+		}
+	}
+
+	assert.Len(stageErrors, 1)
+	assert.Equal("parse", stageErrors[0].Stage)
+	assert.Equal(err, stageErrors[0].Err)
+}
+
+func TestObserveDurations(t *testing.T) {
+	assert := assert.New(t)
+
+	clock := newFakeClock()
+	var events []StageEvent
+	_, err := FromSlice([]int{1}).
+		WithClock(clock).
+		Observe(recordEvents(&events)).
+		Map(func(value int) (int, error) {
+			clock.Advance(time.Second)
+			return value, nil
+		}).
+		Limit(1).
+		Slice()
+
+	assert.Nil(err)
+	assert.Equal(StageEvent{Kind: ElementOut, Stage: "Map", Position: 1, Value: 1, Duration: time.Second}, events[1])
+	assert.Equal(StageEvent{Kind: ElementIn, Stage: "Limit", Position: 2, Value: 1, Duration: time.Second}, events[2])
+	assert.Equal(StageEvent{Kind: ChainFinished, Position: 2, Duration: time.Second}, events[len(events)-1])
+}
+
+func TestObserveOnlyStagesAfterIt(t *testing.T) {
+	assert := assert.New(t)
+
+	var events []StageEvent
+	actualSlice, err := FromSlice([]int{1, 2}).Skip(1).WithClock(newFakeClock()).Observe(recordEvents(&events)).First()
+
+	assert.Equal(2, *actualSlice)
+	assert.Nil(err)
+	assert.Equal([]StageEvent{{Kind: ChainFinished, Position: 1}}, events)
+}
+
+func TestObserveWithMultipleObservers(t *testing.T) {
+	assert := assert.New(t)
+
+	var firstEvents []StageEvent
+	var secondEvents []StageEvent
+	expectedError := errors.New("an example error")
+
+	_, err := FromSlice([]int{1}).
+		Observe(recordEvents(&firstEvents)).
+		Map(func(value int) (int, error) { return 0, expectedError }).
+		Observe(recordEvents(&secondEvents)).
+		Tap(func(int) {}).
+		Slice()
+
+	assert.ErrorIs(err, expectedError)
+	assert.Len(firstEvents, 3)
+	assert.Len(secondEvents, 1)
+	assert.Equal(ChainFinished, secondEvents[0].Kind)
+}
+
+func TestObserveSortCountsSerializingTowardItsFirstValue(t *testing.T) {
+	assert := assert.New(t)
+
+	clock := newFakeClock()
+	var events []StageEvent
+	link := FromSlice([]int{2, 1}).
+		WithClock(clock).
+		Observe(recordEvents(&events)).
+		Map(func(value int) (int, error) {
+			clock.Advance(time.Second)
+			return value, nil
+		}).
+		Sort(func(values []int) func(int, int) bool {
+			return func(i int, j int) bool { return values[i] < values[j] }
+		})
+
+	// Sort serializes the chain when it is called, and reports what it received right away
+	assert.Contains(events, StageEvent{Kind: ElementIn, Stage: "Sort", Position: 2, Value: 1, Duration: time.Second})
+	receivedCount := len(events)
+
+	actualSlice, err := link.Slice()
+
+	assert.Equal([]int{1, 2}, actualSlice)
+	assert.Nil(err)
+	assert.Equal(StageEvent{Kind: ElementOut, Stage: "Sort", Position: 2, Value: 1, Duration: 2 * time.Second}, events[receivedCount])
+	assert.Equal(StageEvent{Kind: ElementOut, Stage: "Sort", Position: 2, Value: 2}, events[receivedCount+1])
+}
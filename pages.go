@@ -0,0 +1,99 @@
+package rangechain
+
+import (
+	"context"
+
+	"github.com/halprin/rangechain/internal/generator"
+)
+
+// PageFetcher requests the page at `cursor`. It returns the page's items, the cursor of the next page, and whether there is a next page at all.
+type PageFetcher[T, C any] func(ctx context.Context, cursor C) (items []T, next C, more bool, err error)
+
+// PageOptions configures how `FromPagesWithOptions` requests pages. The zero value requests each page when it is needed and doesn't retry.
+type PageOptions struct {
+	// Prefetch requests the next page in the background as soon as the current page arrives.
+	Prefetch bool
+	// Retry, if not nil, is called when a request fails with the number of attempts so far and the error. Returning true requests the page again, so it is a good place to wait before the next attempt.
+	Retry func(attempt int, err error) bool
+}
+
+// FromPages starts the chain with the items of every page returned by `fetch`, starting at the cursor `start`. The next page is only requested once the items of the previous page are used up. A failed request generates an error.
+// Chaining and terminating methods can now be called on the result.
+func FromPages[T, C any](ctx context.Context, start C, fetch PageFetcher[T, C]) *Link[T] {
+	return FromPagesWithOptions(ctx, start, fetch, PageOptions{})
+}
+
+// FromPagesWithOptions is like FromPages, but can prefetch and retry pages as configured by `options`.
+// Chaining and terminating methods can now be called on the result.
+func FromPagesWithOptions[T, C any](ctx context.Context, start C, fetch PageFetcher[T, C], options PageOptions) *Link[T] {
+	var items []T
+	currentIndex := 0
+	cursor := start
+	more := true
+	var prefetched chan page[T, C]
+
+	pagesGenerator := func() (T, error) {
+		for currentIndex >= len(items) {
+			if !more {
+				var zero T
+				return zero, generator.Exhausted
+			}
+
+			var result page[T, C]
+			if prefetched != nil {
+				result = <-prefetched
+				prefetched = nil
+			} else {
+				result = fetchPage(ctx, cursor, fetch, options.Retry)
+			}
+
+			if result.err != nil {
+				var zero T
+				return zero, result.err
+			}
+
+			items = result.items
+			currentIndex = 0
+			cursor = result.next
+			more = result.more
+
+			if options.Prefetch && more {
+				prefetched = make(chan page[T, C], 1)
+				go func(cursor C, destination chan<- page[T, C]) {
+					destination <- fetchPage(ctx, cursor, fetch, options.Retry)
+				}(cursor, prefetched)
+			}
+		}
+
+		value := items[currentIndex]
+		currentIndex++
+
+		return value, nil
+	}
+
+	return newLink(pagesGenerator)
+}
+
+type page[T, C any] struct {
+	items []T
+	next  C
+	more  bool
+	err   error
+}
+
+func fetchPage[T, C any](ctx context.Context, cursor C, fetch PageFetcher[T, C], retry func(int, error) bool) page[T, C] {
+	attempt := 0
+
+	for {
+		err := ctx.Err()
+		if err != nil {
+			return page[T, C]{err: err}
+		}
+
+		items, next, more, err := fetch(ctx, cursor)
+		attempt++
+		if err == nil || retry == nil || !retry(attempt, err) {
+			return page[T, C]{items: items, next: next, more: more, err: err}
+		}
+	}
+}
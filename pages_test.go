@@ -0,0 +1,156 @@
+package rangechain
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testPage struct {
+	Items      []int `json:"items"`
+	NextCursor int   `json:"nextCursor"`
+	More       bool  `json:"more"`
+}
+
+// newTestPageServer serves `pageCount` pages of three numbers each. The page is selected by the `cursor` query parameter.
+func newTestPageServer(pageCount int, requests *atomic.Int32, failFirst *atomic.Int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		requests.Add(1)
+		if failFirst != nil && failFirst.Add(-1) >= 0 {
+			writer.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		cursor, _ := strconv.Atoi(request.URL.Query().Get("cursor"))
+		response := testPage{
+			Items:      []int{cursor * 3, cursor*3 + 1, cursor*3 + 2},
+			NextCursor: cursor + 1,
+			More:       cursor+1 < pageCount,
+		}
+		_ = json.NewEncoder(writer).Encode(response)
+	}))
+}
+
+func testPageFetcher(server *httptest.Server) PageFetcher[int, int] {
+	return func(ctx context.Context, cursor int) ([]int, int, bool, error) {
+		request, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s?cursor=%d", server.URL, cursor), nil)
+		if err != nil {
+			return nil, 0, false, err
+		}
+
+		response, err := server.Client().Do(request)
+		if err != nil {
+			return nil, 0, false, err
+		}
+		defer response.Body.Close()
+
+		if response.StatusCode != http.StatusOK {
+			return nil, 0, false, errors.New(response.Status)
+		}
+
+		var decoded testPage
+		err = json.NewDecoder(response.Body).Decode(&decoded)
+		return decoded.Items, decoded.NextCursor, decoded.More, err
+	}
+}
+
+func TestFromPages(t *testing.T) {
+	assert := assert.New(t)
+
+	var requests atomic.Int32
+	server := newTestPageServer(3, &requests, nil)
+	defer server.Close()
+
+	actualSlice, err := FromPages(context.Background(), 0, testPageFetcher(server)).Slice()
+
+	assert.Equal([]int{0, 1, 2, 3, 4, 5, 6, 7, 8}, actualSlice)
+	assert.Nil(err)
+	assert.Equal(int32(3), requests.Load())
+}
+
+func TestFromPagesOnlyRequestsWhatIsNeeded(t *testing.T) {
+	assert := assert.New(t)
+
+	var requests atomic.Int32
+	server := newTestPageServer(10, &requests, nil)
+	defer server.Close()
+
+	actualSlice, err := FromPages(context.Background(), 0, testPageFetcher(server)).Limit(4).Slice()
+
+	assert.Equal([]int{0, 1, 2, 3}, actualSlice)
+	assert.Nil(err)
+	assert.Equal(int32(2), requests.Load())
+}
+
+func TestFromPagesWithPrefetch(t *testing.T) {
+	assert := assert.New(t)
+
+	var requests atomic.Int32
+	server := newTestPageServer(3, &requests, nil)
+	defer server.Close()
+
+	first, err := FromPagesWithOptions(context.Background(), 0, testPageFetcher(server), PageOptions{Prefetch: true}).First()
+
+	assert.Equal(0, *first)
+	assert.Nil(err)
+	assert.Eventually(func() bool { return requests.Load() == 2 }, time.Second, time.Millisecond)
+}
+
+func TestFromPagesWithRetry(t *testing.T) {
+	assert := assert.New(t)
+
+	var requests atomic.Int32
+	var failFirst atomic.Int32
+	failFirst.Store(2)
+	server := newTestPageServer(2, &requests, &failFirst)
+	defer server.Close()
+
+	var attempts []int
+	options := PageOptions{
+		Retry: func(attempt int, err error) bool {
+			attempts = append(attempts, attempt)
+			return attempt < 3
+		},
+	}
+
+	actualSlice, err := FromPagesWithOptions(context.Background(), 0, testPageFetcher(server), options).Slice()
+
+	assert.Equal([]int{0, 1, 2, 3, 4, 5}, actualSlice)
+	assert.Nil(err)
+	assert.Equal([]int{1, 2}, attempts)
+}
+
+func TestFromPagesHasError(t *testing.T) {
+	assert := assert.New(t)
+
+	var requests atomic.Int32
+	var failFirst atomic.Int32
+	failFirst.Store(1)
+	server := newTestPageServer(2, &requests, &failFirst)
+	defer server.Close()
+
+	_, err := FromPages(context.Background(), 0, testPageFetcher(server)).Slice()
+
+	assert.NotNil(err)
+	assert.Contains(err.Error(), "503")
+}
+
+func TestFromPagesWithCanceledContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := FromPages(ctx, 0, func(ctx context.Context, cursor int) ([]int, int, bool, error) {
+		return []int{1}, 0, false, nil
+	}).Slice()
+
+	assert.ErrorIs(t, err, context.Canceled)
+}
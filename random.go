@@ -0,0 +1,87 @@
+package rangechain
+
+import (
+	"errors"
+	"math/rand/v2"
+
+	"github.com/halprin/rangechain/internal/generator"
+)
+
+// FromRand starts an infinite chain of values made by calling `randomFunction` with `random`. Seed `random` to get the same values every time. Use `Limit` or a short-circuiting terminating method to stop it.
+// Chaining and terminating methods can now be called on the result.
+func FromRand[T any](random *rand.Rand, randomFunction func(*rand.Rand) T) *Link[T] {
+	randGenerator := func() (T, error) {
+		return randomFunction(random), nil
+	}
+
+	return newLink(randGenerator)
+}
+
+// Sample keeps `k` values chosen uniformly at random from the chain, using `random` for the choices. Reservoir sampling is used, so only `k` values are held in memory no matter how long the chain is, but the chain must end. The kept values stay in the order they were chosen in. Fewer than `k` values are kept if the chain is shorter than `k`.
+func (receiver *Link[T]) Sample(k int, random *rand.Rand) *Link[T] {
+	var reservoir []T
+	computedValues := false
+	currentIndex := 0
+
+	sampleGenerator := func() (T, error) {
+		if !computedValues {
+			seen := 0
+			for {
+				value, err := receiver.generator()
+				if err != nil {
+					if errors.Is(err, generator.Exhausted) {
+						break
+					}
+					return value, err
+				}
+
+				if len(reservoir) < k {
+					reservoir = append(reservoir, value)
+				} else if replaceIndex := random.IntN(seen + 1); replaceIndex < k {
+					reservoir[replaceIndex] = value
+				}
+				seen++
+			}
+			computedValues = true
+		}
+
+		if currentIndex >= len(reservoir) {
+			var zero T
+			return zero, generator.Exhausted
+		}
+
+		value := reservoir[currentIndex]
+		currentIndex++
+
+		return value, nil
+	}
+
+	return newChainedLink(receiver, "Sample", sampleGenerator)
+}
+
+// SampleFraction keeps each value in the chain independently with probability `probability`, using `random` for the choices. The chain is streamed, so it can be infinite.
+func (receiver *Link[T]) SampleFraction(probability float64, random *rand.Rand) *Link[T] {
+	sampleGenerator := func() (T, error) {
+		for {
+			value, err := receiver.generator()
+			if err != nil {
+				return value, err
+			}
+
+			if random.Float64() < probability {
+				return value, nil
+			}
+		}
+	}
+
+	return newChainedLink(receiver, "SampleFraction", sampleGenerator)
+}
+
+// Shuffle puts the chain into a random order, using `random` for the choices. Expensive because it serializes the chain first.
+func (receiver *Link[T]) Shuffle(random *rand.Rand) *Link[T] {
+	return receiver.serializedLink("Shuffle", func(serializedSlice []T) {
+		random.Shuffle(len(serializedSlice), func(i int, j int) {
+			serializedSlice[i], serializedSlice[j] = serializedSlice[j], serializedSlice[i]
+		})
+	})
+}
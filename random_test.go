@@ -0,0 +1,109 @@
+package rangechain
+
+import (
+	"errors"
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestRand() *rand.Rand {
+	return rand.New(rand.NewPCG(19840124, 26))
+}
+
+func TestFromRandIsReproducible(t *testing.T) {
+	assert := assert.New(t)
+
+	dice := func(random *rand.Rand) int { return random.IntN(6) + 1 }
+
+	firstSlice, err := FromRand(newTestRand(), dice).Limit(20).Slice()
+	assert.Nil(err)
+	secondSlice, err := FromRand(newTestRand(), dice).Limit(20).Slice()
+	assert.Nil(err)
+
+	assert.Len(firstSlice, 20)
+	assert.Equal(firstSlice, secondSlice)
+	for _, value := range firstSlice {
+		assert.True(value >= 1 && value <= 6)
+	}
+}
+
+func TestSample(t *testing.T) {
+	assert := assert.New(t)
+
+	actualSlice, err := Range(0, 1000, 1).Sample(5, newTestRand()).Slice()
+
+	assert.Nil(err)
+	assert.Len(actualSlice, 5)
+	againSlice, _ := Range(0, 1000, 1).Sample(5, newTestRand()).Slice()
+	assert.Equal(actualSlice, againSlice)
+	slices.Sort(actualSlice)
+	assert.Len(slices.Compact(actualSlice), 5)
+}
+
+func TestSampleShorterThanK(t *testing.T) {
+	assert := assert.New(t)
+
+	actualSlice, err := FromSlice([]int{1, 2, 3}).Sample(5, newTestRand()).Slice()
+
+	assert.Equal([]int{1, 2, 3}, actualSlice)
+	assert.Nil(err)
+}
+
+func TestSampleHasError(t *testing.T) {
+	expectedError := errors.New("an example error")
+	link := newLink(createGeneratorWithError([]int{1, 2, 3}, 2, expectedError))
+
+	_, err := link.Sample(2, newTestRand()).Slice()
+
+	assert.Equal(t, expectedError, err)
+}
+
+func TestSampleFraction(t *testing.T) {
+	assert := assert.New(t)
+
+	count, err := Naturals().Limit(10000).SampleFraction(0.25, newTestRand()).Count()
+
+	assert.Nil(err)
+	assert.InDelta(2500, count, 200)
+}
+
+func TestSampleFractionOfInfiniteChain(t *testing.T) {
+	assert := assert.New(t)
+
+	actualSlice, err := Naturals().SampleFraction(0.5, newTestRand()).Limit(3).Slice()
+
+	assert.Nil(err)
+	assert.Len(actualSlice, 3)
+	assert.True(slices.IsSorted(actualSlice))
+}
+
+func TestShuffle(t *testing.T) {
+	assert := assert.New(t)
+
+	inputSlice := []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}
+
+	actualSlice, err := FromSlice(slices.Clone(inputSlice)).Shuffle(newTestRand()).Slice()
+
+	assert.Nil(err)
+	assert.NotEqual(inputSlice, actualSlice)
+	assert.ElementsMatch(inputSlice, actualSlice)
+}
+
+func TestShuffleSerializesWhenCalled(t *testing.T) {
+	assert := assert.New(t)
+
+	pulled := 0
+	link := FromSlice([]int{1, 2, 3}).Tap(func(int) { pulled++ })
+
+	shuffled := link.Shuffle(newTestRand())
+
+	assert.Equal(3, pulled)
+
+	actualSlice, err := shuffled.Slice()
+
+	assert.ElementsMatch([]int{1, 2, 3}, actualSlice)
+	assert.Nil(err)
+}
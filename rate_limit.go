@@ -0,0 +1,133 @@
+package rangechain
+
+import (
+	"fmt"
+	"time"
+)
+
+// RateLimit paces the chain so that, on average, at most `eventsPerSecond` values are delivered each second. It acts as a token bucket holding `burst` tokens: up to `burst` values can be delivered back to back after a quiet period, then each value waits for the next token. Errors, and the end of the chain, are delivered without waiting. Placed before `MapParallel` or `ForEachParallel`, it paces how fast their invocations start.
+func (receiver *Link[T]) RateLimit(eventsPerSecond float64, burst int) *Link[T] {
+	clock := receiver.currentClock()
+	interval := time.Duration(float64(time.Second) / eventsPerSecond)
+	tolerance := interval * time.Duration(burst-1)
+	// the time the next token is refilled when none are spent before then
+	var nextToken time.Time
+
+	rateLimitGenerator := func() (T, error) {
+		if eventsPerSecond <= 0 || burst < 1 {
+			var zero T
+			return zero, fmt.Errorf("rate limit: %v events per second with a burst of %d must both be positive", eventsPerSecond, burst)
+		}
+
+		value, err := receiver.generator()
+		if err != nil {
+			return value, err
+		}
+
+		now := clock.Now()
+		if nextToken.Before(now) {
+			nextToken = now
+		}
+
+		wait := nextToken.Sub(now) - tolerance
+		if wait > 0 {
+			<-clock.After(wait)
+		}
+		nextToken = nextToken.Add(interval)
+
+		return value, nil
+	}
+
+	return newChainedLink(receiver, "RateLimit", rateLimitGenerator)
+}
+
+// Throttle delivers a value and then drops every value that arrives within `interval` of it. The next value that arrives after `interval` has passed is delivered, and the interval starts again. Errors are always delivered.
+func (receiver *Link[T]) Throttle(interval time.Duration) *Link[T] {
+	clock := receiver.currentClock()
+	var lastDelivered time.Time
+	delivered := false
+
+	throttleGenerator := func() (T, error) {
+		for {
+			value, err := receiver.generator()
+			if err != nil {
+				return value, err
+			}
+
+			now := clock.Now()
+			if delivered && now.Sub(lastDelivered) < interval {
+				continue
+			}
+
+			lastDelivered = now
+			delivered = true
+
+			return value, nil
+		}
+	}
+
+	return newChainedLink(receiver, "Throttle", throttleGenerator)
+}
+
+// Debounce delivers a value only once no other value has arrived for `quiet`, so a burst of values is reduced to its last one. When the chain ends, a value still waiting is delivered right away. Values are read from earlier in the chain in the background, which suits bursty sources like `FromChannel`. If the chain is abandoned before it ends, for example by `Limit` or `First`, the background reading stays blocked forever, holding on to the value it last read.
+func (receiver *Link[T]) Debounce(quiet time.Duration) *Link[T] {
+	clock := receiver.currentClock()
+	var arrivals chan arrival[T]
+	var pending T
+	hasPending := false
+	var finalErr error
+
+	debounceGenerator := func() (T, error) {
+		var zero T
+
+		if arrivals == nil {
+			arrivals = make(chan arrival[T])
+			go func() {
+				for {
+					value, err := receiver.generator()
+					arrivals <- arrival[T]{value: value, err: err}
+					if err != nil {
+						return
+					}
+				}
+			}()
+		}
+
+		for {
+			if finalErr != nil {
+				return zero, finalErr
+			}
+
+			if !hasPending {
+				next := <-arrivals
+				if next.err != nil {
+					finalErr = next.err
+					return zero, finalErr
+				}
+				pending = next.value
+				hasPending = true
+			}
+
+			select {
+			case next := <-arrivals:
+				if next.err != nil {
+					finalErr = next.err
+					hasPending = false
+					return pending, nil
+				}
+				pending = next.value
+			case <-clock.After(quiet):
+				hasPending = false
+				return pending, nil
+			}
+		}
+	}
+
+	return newChainedLink(receiver, "Debounce", debounceGenerator)
+}
+
+// arrival is a value, or error, handed over by a background goroutine.
+type arrival[T any] struct {
+	value T
+	err   error
+}
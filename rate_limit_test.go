@@ -0,0 +1,132 @@
+package rangechain
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimit(t *testing.T) {
+	assert := assert.New(t)
+
+	clock := newFakeClock()
+	clock.autoAdvance = true
+	start := clock.Now()
+
+	actualSlice, err := FromSlice([]int{1, 2, 3, 4, 5}).WithClock(clock).RateLimit(2, 2).Slice()
+
+	assert.Equal([]int{1, 2, 3, 4, 5}, actualSlice)
+	assert.Nil(err)
+	// the first two use up the burst, the remaining three wait half a second each
+	assert.Equal(1500*time.Millisecond, clock.Now().Sub(start))
+}
+
+func TestRateLimitRefillsAfterQuietPeriod(t *testing.T) {
+	assert := assert.New(t)
+
+	clock := newFakeClock()
+	clock.autoAdvance = true
+
+	link := FromSlice([]int{1, 2, 3, 4, 5}).WithClock(clock).RateLimit(1, 3)
+
+	_, err := link.Limit(3).Slice()
+	assert.Nil(err)
+	assert.Equal(0, clock.AfterCalls())
+
+	clock.Advance(10 * time.Second)
+	start := clock.Now()
+
+	actualSlice, err := link.Slice()
+
+	assert.Equal([]int{4, 5}, actualSlice)
+	assert.Nil(err)
+	assert.Equal(time.Duration(0), clock.Now().Sub(start))
+}
+
+func TestRateLimitWithSystemClock(t *testing.T) {
+	assert := assert.New(t)
+
+	actualSlice, err := FromSlice([]int{1, 2, 3}).RateLimit(1000, 1).Slice()
+
+	assert.Equal([]int{1, 2, 3}, actualSlice)
+	assert.Nil(err)
+}
+
+func TestRateLimitHasInvalidRate(t *testing.T) {
+	assert := assert.New(t)
+
+	actualSlice, err := FromSlice([]int{1, 2, 3}).RateLimit(0, 1).Slice()
+
+	assert.Equal([]int{}, actualSlice)
+	assert.NotNil(err)
+}
+
+func TestThrottle(t *testing.T) {
+	assert := assert.New(t)
+
+	clock := newFakeClock()
+	arriveEvery40Milliseconds := func(value int) (int, error) {
+		clock.Advance(40 * time.Millisecond)
+		return value, nil
+	}
+
+	actualSlice, err := FromSlice([]int{1, 2, 3, 4, 5, 6, 7}).WithClock(clock).Map(arriveEvery40Milliseconds).Throttle(100 * time.Millisecond).Slice()
+
+	assert.Equal([]int{1, 4, 7}, actualSlice)
+	assert.Nil(err)
+}
+
+func TestThrottleHasError(t *testing.T) {
+	assert := assert.New(t)
+
+	expectedError := errors.New("an example error")
+	link := newLink(createGeneratorWithError([]int{1, 2, 3}, 2, expectedError))
+
+	actualSlice, err := link.WithClock(newFakeClock()).Throttle(time.Hour).Slice()
+
+	assert.Equal([]int{1}, actualSlice)
+	assert.Equal(expectedError, err)
+}
+
+func TestDebounce(t *testing.T) {
+	assert := assert.New(t)
+
+	clock := newFakeClock()
+	channel := make(chan int)
+	results := make(chan []int)
+
+	go func() {
+		actualSlice, err := FromChannel(channel).WithClock(clock).Debounce(100 * time.Millisecond).Slice()
+		assert.Nil(err)
+		results <- actualSlice
+	}()
+
+	// a burst of three values is reduced to the last one once the quiet period passes
+	channel <- 1
+	channel <- 2
+	channel <- 3
+	assert.Eventually(func() bool { return clock.AfterCalls() == 3 }, time.Second, time.Millisecond)
+	clock.Advance(100 * time.Millisecond)
+
+	// a value still waiting when the channel closes is delivered right away
+	channel <- 4
+	channel <- 5
+	assert.Eventually(func() bool { return clock.AfterCalls() == 5 }, time.Second, time.Millisecond)
+	close(channel)
+
+	assert.Equal([]int{3, 5}, <-results)
+}
+
+func TestDebounceHasError(t *testing.T) {
+	assert := assert.New(t)
+
+	expectedError := errors.New("an example error")
+	link := newLink(createGeneratorWithError([]int{1, 2, 3}, 2, expectedError))
+
+	actualSlice, err := link.WithClock(newFakeClock()).Debounce(time.Hour).Slice()
+
+	assert.Equal([]int{1}, actualSlice)
+	assert.Equal(expectedError, err)
+}
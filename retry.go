@@ -0,0 +1,100 @@
+package rangechain
+
+import (
+	"context"
+	"math/rand/v2"
+	"time"
+)
+
+// RetryPolicy configures how `MapRetry` and `Retrying` retry a failing function. The zero value tries once.
+type RetryPolicy struct {
+	// Attempts is the most times the function is called for one value, including the first call.
+	Attempts int
+	// InitialBackoff is how long to wait before the first retry.
+	InitialBackoff time.Duration
+	// MaxBackoff, if not zero, caps how long to wait before any retry.
+	MaxBackoff time.Duration
+	// Multiplier grows the wait after each retry. Zero means 2.
+	Multiplier float64
+	// Jitter randomly spreads each wait by up to this fraction of it in either direction, e.g. 0.1 for ±10%.
+	Jitter float64
+	// Retryable, if not nil, is asked whether an error is worth retrying. `nil` retries every error.
+	Retryable func(error) bool
+	// Context, if not nil, stops the retrying when it is done. Its error is returned instead.
+	Context context.Context
+	// Sleep, if not nil, replaces waiting on a real timer. It should wait for the duration or until the context is done, whichever is first, and return the context's error in the latter case.
+	Sleep func(ctx context.Context, duration time.Duration) error
+}
+
+// MapRetry is like Map, but calls `mapFunction` again when it fails, as configured by `policy`. The error of the last attempt is the one handled.
+func (receiver *Link[T]) MapRetry[U any](mapFunction func(T) (U, error), policy RetryPolicy) *Link[U] {
+	return receiver.Label(receiver.stageName("MapRetry")).Map(Retrying(mapFunction, policy))
+}
+
+// Retrying wraps `function` so that it is called again when it fails, as configured by `policy`. Pass the result to `MapParallel` so each value retries independently of the others.
+func Retrying[T, U any](function func(T) (U, error), policy RetryPolicy) func(T) (U, error) {
+	return func(value T) (U, error) {
+		ctx := policy.Context
+		if ctx == nil {
+			ctx = context.Background()
+		}
+
+		backoff := policy.InitialBackoff
+		attempt := 1
+
+		for {
+			result, err := function(value)
+			if err == nil || attempt >= policy.Attempts || (policy.Retryable != nil && !policy.Retryable(err)) {
+				return result, err
+			}
+
+			sleepErr := policy.sleep(ctx, policy.jittered(backoff))
+			if sleepErr != nil {
+				var zero U
+				return zero, sleepErr
+			}
+
+			backoff = policy.nextBackoff(backoff)
+			attempt++
+		}
+	}
+}
+
+func (receiver RetryPolicy) nextBackoff(backoff time.Duration) time.Duration {
+	multiplier := receiver.Multiplier
+	if multiplier == 0 {
+		multiplier = 2
+	}
+
+	backoff = time.Duration(float64(backoff) * multiplier)
+	if receiver.MaxBackoff > 0 && backoff > receiver.MaxBackoff {
+		backoff = receiver.MaxBackoff
+	}
+
+	return backoff
+}
+
+func (receiver RetryPolicy) jittered(backoff time.Duration) time.Duration {
+	if receiver.Jitter == 0 {
+		return backoff
+	}
+
+	spread := float64(backoff) * receiver.Jitter * (2*rand.Float64() - 1)
+	return max(0, backoff+time.Duration(spread))
+}
+
+func (receiver RetryPolicy) sleep(ctx context.Context, duration time.Duration) error {
+	if receiver.Sleep != nil {
+		return receiver.Sleep(ctx, duration)
+	}
+
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,127 @@
+package rangechain
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeSleeper records the waits it was asked for instead of waiting.
+type fakeSleeper struct {
+	mutex sync.Mutex
+	waits []time.Duration
+}
+
+func (receiver *fakeSleeper) sleep(ctx context.Context, duration time.Duration) error {
+	receiver.mutex.Lock()
+	defer receiver.mutex.Unlock()
+
+	receiver.waits = append(receiver.waits, duration)
+	return ctx.Err()
+}
+
+// flakyFunction fails the first `failures` times it is called for each value.
+func flakyFunction(failures int, err error) func(int) (int, error) {
+	var mutex sync.Mutex
+	calls := map[int]int{}
+
+	return func(value int) (int, error) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		calls[value]++
+		if calls[value] <= failures {
+			return 0, err
+		}
+		return value * 10, nil
+	}
+}
+
+func TestMapRetry(t *testing.T) {
+	assert := assert.New(t)
+
+	sleeper := &fakeSleeper{}
+	policy := RetryPolicy{Attempts: 4, InitialBackoff: time.Second, MaxBackoff: 3 * time.Second, Sleep: sleeper.sleep}
+
+	actualSlice, err := FromSlice([]int{1}).MapRetry(flakyFunction(3, errors.New("an example error")), policy).Slice()
+
+	assert.Equal([]int{10}, actualSlice)
+	assert.Nil(err)
+	assert.Equal([]time.Duration{time.Second, 2 * time.Second, 3 * time.Second}, sleeper.waits)
+}
+
+func TestMapRetryRunsOutOfAttempts(t *testing.T) {
+	assert := assert.New(t)
+
+	expectedError := errors.New("an example error")
+	sleeper := &fakeSleeper{}
+	policy := RetryPolicy{Attempts: 2, Sleep: sleeper.sleep}
+
+	_, err := FromSlice([]int{1}).MapRetry(flakyFunction(5, expectedError), policy).Slice()
+
+	assert.ErrorIs(err, expectedError)
+	var chainError *ChainError
+	assert.True(errors.As(err, &chainError))
+	assert.Equal("MapRetry", chainError.Stage)
+	assert.Len(sleeper.waits, 1)
+}
+
+func TestMapRetryOnlyRetriesRetryableErrors(t *testing.T) {
+	assert := assert.New(t)
+
+	expectedError := errors.New("an example error")
+	sleeper := &fakeSleeper{}
+	policy := RetryPolicy{
+		Attempts:  5,
+		Retryable: func(err error) bool { return !errors.Is(err, expectedError) },
+		Sleep:     sleeper.sleep,
+	}
+
+	_, err := FromSlice([]int{1}).MapRetry(flakyFunction(1, expectedError), policy).Slice()
+
+	assert.ErrorIs(err, expectedError)
+	assert.Empty(sleeper.waits)
+}
+
+func TestMapRetryStopsWhenContextIsDone(t *testing.T) {
+	assert := assert.New(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	policy := RetryPolicy{Attempts: 5, InitialBackoff: time.Hour, Context: ctx}
+
+	_, err := FromSlice([]int{1}).MapRetry(flakyFunction(1, errors.New("an example error")), policy).Slice()
+
+	assert.ErrorIs(err, context.Canceled)
+}
+
+func TestRetryingWithJitter(t *testing.T) {
+	assert := assert.New(t)
+
+	sleeper := &fakeSleeper{}
+	policy := RetryPolicy{Attempts: 3, InitialBackoff: time.Second, Jitter: 0.5, Sleep: sleeper.sleep}
+
+	_, err := Retrying(flakyFunction(2, errors.New("an example error")), policy)(1)
+
+	assert.Nil(err)
+	assert.Len(sleeper.waits, 2)
+	assert.InDelta(time.Second, sleeper.waits[0], float64(time.Second/2))
+	assert.InDelta(2*time.Second, sleeper.waits[1], float64(time.Second))
+}
+
+func TestRetryingWithMapParallel(t *testing.T) {
+	assert := assert.New(t)
+
+	sleeper := &fakeSleeper{}
+	policy := RetryPolicy{Attempts: 3, InitialBackoff: time.Millisecond, Sleep: sleeper.sleep}
+
+	actualSlice, err := FromSlice([]int{1, 2, 3}).MapParallel(Retrying(flakyFunction(2, errors.New("an example error")), policy)).Slice()
+
+	assert.Equal([]int{10, 20, 30}, actualSlice)
+	assert.Nil(err)
+	assert.Len(sleeper.waits, 6)
+}
@@ -0,0 +1,246 @@
+package rangechain
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"reflect"
+	"runtime"
+	"strings"
+
+	"github.com/halprin/rangechain/internal/generator"
+)
+
+// FromRows starts the chain with the rows of a query, each converted by `scan`. `scan` is called after `rows.Next` and typically calls `rows.Scan`; `ScanStruct` builds one for structs. The rows are closed when they run out, when an error occurs, or when the chain is garbage collected without being finished. An error from `rows.Err` is passed down the chain.
+// Chaining and terminating methods can now be called on the result.
+func FromRows[T any](rows *sql.Rows, scan func(*sql.Rows) (T, error)) *Link[T] {
+	// the cleanup is tied to the state rather than the link because chaining methods like Skip reuse the generator without the link
+	state := &rowsState{rows: rows}
+
+	rowsGenerator := func() (T, error) {
+		var zero T
+
+		if state.closed {
+			return zero, generator.Exhausted
+		}
+
+		if !state.rows.Next() {
+			state.closed = true
+
+			err := state.rows.Err()
+			closeErr := state.rows.Close()
+			if err != nil {
+				return zero, err
+			} else if closeErr != nil {
+				return zero, closeErr
+			}
+
+			return zero, generator.Exhausted
+		}
+
+		value, err := scan(state.rows)
+		if err != nil {
+			state.closed = true
+			_ = state.rows.Close()
+			return zero, err
+		}
+
+		return value, nil
+	}
+
+	runtime.AddCleanup(state, func(rows *sql.Rows) {
+		_ = rows.Close()
+	}, rows)
+
+	return newLink(rowsGenerator)
+}
+
+type rowsState struct {
+	rows   *sql.Rows
+	closed bool
+}
+
+// ScanStruct returns a scan function for `FromRows` that fills in the struct `T` from each row. A column maps to the exported field whose `db` tag matches the column name; untagged fields match their name, ignoring case. A tag of "-" ignores the field. A nil pointer to an embedded struct is allocated when one of its fields has a column. Columns without a field are discarded.
+func ScanStruct[T any]() func(*sql.Rows) (T, error) {
+	var fieldIndices [][]int
+
+	return func(rows *sql.Rows) (T, error) {
+		var value T
+
+		if fieldIndices == nil {
+			var err error
+			fieldIndices, err = sqlFieldIndices(rows, reflect.TypeFor[T]())
+			if err != nil {
+				return value, err
+			}
+		}
+
+		structValue := reflect.ValueOf(&value).Elem()
+		destinations := make([]any, len(fieldIndices))
+		for index, fieldIndex := range fieldIndices {
+			if fieldIndex == nil {
+				destinations[index] = new(any)
+				continue
+			}
+
+			fieldValue, err := settableField(structValue, fieldIndex)
+			if err != nil {
+				return value, fmt.Errorf("sql: %w", err)
+			}
+			destinations[index] = fieldValue.Addr().Interface()
+		}
+
+		err := rows.Scan(destinations...)
+
+		return value, err
+	}
+}
+
+// sqlFieldIndices finds the struct field for each column of `rows`. A `nil` entry means the column has no field.
+func sqlFieldIndices(rows *sql.Rows, structType reflect.Type) ([][]int, error) {
+	if structType.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("sql: %v is not a struct", structType)
+	}
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+
+	tagged := map[string][]int{}
+	named := map[string][]int{}
+	for _, field := range reflect.VisibleFields(structType) {
+		if !field.IsExported() || field.Anonymous {
+			continue
+		}
+
+		tag, ok := field.Tag.Lookup("db")
+		if tag == "-" {
+			continue
+		} else if ok && tag != "" {
+			tagged[tag] = field.Index
+		} else {
+			named[strings.ToLower(field.Name)] = field.Index
+		}
+	}
+
+	fieldIndices := make([][]int, len(columns))
+	for index, column := range columns {
+		if fieldIndex, ok := tagged[column]; ok {
+			fieldIndices[index] = fieldIndex
+		} else if fieldIndex, ok := named[strings.ToLower(column)]; ok {
+			fieldIndices[index] = fieldIndex
+		}
+	}
+
+	return fieldIndices, nil
+}
+
+// SQLBatchPolicy decides what `WriteSQLBatches` does when a batch fails. The zero value tries each batch once and stops at the first failure.
+type SQLBatchPolicy struct {
+	// Retries is how many more times a failed batch is attempted, each in a new transaction.
+	Retries int
+	// Retryable, if not nil, is asked whether an error is worth retrying. `nil` retries every error.
+	Retryable func(error) bool
+	// ContinueOnFailure keeps writing later batches after a batch fails every attempt.
+	ContinueOnFailure bool
+}
+
+// SQLBatchFailure is a batch that `WriteSQLBatches` rolled back. `Index` is the zero-based position of the batch.
+type SQLBatchFailure[T any] struct {
+	Index  int
+	Values []T
+	Err    error
+}
+
+// SQLBatchReport is what `WriteSQLBatches` did.
+type SQLBatchReport[T any] struct {
+	RowsWritten   int
+	FailedBatches []SQLBatchFailure[T]
+}
+
+// WriteSQLBatches groups the values in the chain into batches of `batchSize` and executes each batch inside its own transaction on `db`. `buildStmt` converts a value into the statement and arguments to execute for it. A batch whose statement fails is rolled back, then retried or skipped depending on `policy`. Returns a report of the rows written and the batches that failed. Values generated before a chain error are still written. Also returns an error if the chain generated one, or if a batch failed and `policy` doesn't continue on failure.
+func (receiver *Link[T]) WriteSQLBatches(db *sql.DB, batchSize int, buildStmt func(T) (string, []any, error), policy SQLBatchPolicy) (SQLBatchReport[T], error) {
+	defer receiver.finished(receiver.currentClock().Now())
+
+	report := SQLBatchReport[T]{}
+	if batchSize < 1 {
+		return report, fmt.Errorf("sql: batch size %d must be positive", batchSize)
+	}
+
+	batchIndex := 0
+
+	for {
+		batch, chainErr := receiver.nextBatch(batchSize)
+		if len(batch) > 0 {
+			err := writeSQLBatch(db, batch, buildStmt, policy)
+			if err != nil {
+				report.FailedBatches = append(report.FailedBatches, SQLBatchFailure[T]{Index: batchIndex, Values: batch, Err: err})
+				if !policy.ContinueOnFailure {
+					return report, err
+				}
+			} else {
+				report.RowsWritten += len(batch)
+			}
+			batchIndex++
+		}
+
+		if chainErr != nil {
+			return report, chainErr
+		} else if len(batch) < batchSize {
+			return report, nil
+		}
+	}
+}
+
+// nextBatch pulls up to `batchSize` values from the chain. The batch is shorter only if the chain is exhausted or generated an error.
+func (receiver *Link[T]) nextBatch(batchSize int) ([]T, error) {
+	batch := make([]T, 0, batchSize)
+
+	for len(batch) < batchSize {
+		currentValue, err := receiver.generator()
+		if err != nil {
+			if errors.Is(err, generator.Exhausted) {
+				return batch, nil
+			}
+			return batch, err
+		}
+
+		batch = append(batch, currentValue)
+	}
+
+	return batch, nil
+}
+
+func writeSQLBatch[T any](db *sql.DB, batch []T, buildStmt func(T) (string, []any, error), policy SQLBatchPolicy) error {
+	var err error
+
+	for attempt := 0; attempt <= policy.Retries; attempt++ {
+		err = writeSQLBatchInTransaction(db, batch, buildStmt)
+		if err == nil || (policy.Retryable != nil && !policy.Retryable(err)) {
+			return err
+		}
+	}
+
+	return err
+}
+
+func writeSQLBatchInTransaction[T any](db *sql.DB, batch []T, buildStmt func(T) (string, []any, error)) error {
+	transaction, err := db.Begin()
+	if err != nil {
+		return err
+	}
+
+	for _, value := range batch {
+		query, args, err := buildStmt(value)
+		if err == nil {
+			_, err = transaction.Exec(query, args...)
+		}
+
+		if err != nil {
+			return errors.Join(err, transaction.Rollback())
+		}
+	}
+
+	return transaction.Commit()
+}
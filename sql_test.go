@@ -0,0 +1,434 @@
+package rangechain
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeDatabase is an in-process database/sql driver. Every query returns `columns` and `rows`, and every exec is recorded.
+type fakeDatabase struct {
+	mutex      sync.Mutex
+	columns    []string
+	rows       [][]driver.Value
+	rowsError  error
+	closedRows int
+	execs      [][]driver.Value
+	execError  func(args []driver.Value) error
+	commits    int
+	rollbacks  int
+}
+
+func (receiver *fakeDatabase) open() *sql.DB {
+	return sql.OpenDB(receiver)
+}
+
+func (receiver *fakeDatabase) Connect(context.Context) (driver.Conn, error) {
+	return &fakeConn{database: receiver}, nil
+}
+
+func (receiver *fakeDatabase) Driver() driver.Driver {
+	return nil
+}
+
+type fakeConn struct {
+	database *fakeDatabase
+	tx       *fakeTx
+}
+
+func (receiver *fakeConn) Prepare(query string) (driver.Stmt, error) {
+	return &fakeStmt{conn: receiver}, nil
+}
+
+func (receiver *fakeConn) Close() error {
+	return nil
+}
+
+func (receiver *fakeConn) Begin() (driver.Tx, error) {
+	receiver.tx = &fakeTx{conn: receiver}
+	return receiver.tx, nil
+}
+
+type fakeTx struct {
+	conn  *fakeConn
+	execs [][]driver.Value
+}
+
+func (receiver *fakeTx) Commit() error {
+	database := receiver.conn.database
+	database.mutex.Lock()
+	defer database.mutex.Unlock()
+
+	database.execs = append(database.execs, receiver.execs...)
+	database.commits++
+	receiver.conn.tx = nil
+	return nil
+}
+
+func (receiver *fakeTx) Rollback() error {
+	database := receiver.conn.database
+	database.mutex.Lock()
+	defer database.mutex.Unlock()
+
+	database.rollbacks++
+	receiver.conn.tx = nil
+	return nil
+}
+
+type fakeStmt struct {
+	conn *fakeConn
+}
+
+func (receiver *fakeStmt) Close() error {
+	return nil
+}
+
+func (receiver *fakeStmt) NumInput() int {
+	return -1
+}
+
+func (receiver *fakeStmt) Exec(args []driver.Value) (driver.Result, error) {
+	database := receiver.conn.database
+	if database.execError != nil {
+		err := database.execError(args)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if receiver.conn.tx != nil {
+		receiver.conn.tx.execs = append(receiver.conn.tx.execs, args)
+	} else {
+		database.mutex.Lock()
+		database.execs = append(database.execs, args)
+		database.mutex.Unlock()
+	}
+
+	return driver.RowsAffected(1), nil
+}
+
+func (receiver *fakeStmt) Query(args []driver.Value) (driver.Rows, error) {
+	return &fakeRows{database: receiver.conn.database}, nil
+}
+
+type fakeRows struct {
+	database *fakeDatabase
+	index    int
+}
+
+func (receiver *fakeRows) Columns() []string {
+	return receiver.database.columns
+}
+
+func (receiver *fakeRows) Close() error {
+	receiver.database.mutex.Lock()
+	defer receiver.database.mutex.Unlock()
+
+	receiver.database.closedRows++
+	return nil
+}
+
+func (receiver *fakeRows) Next(dest []driver.Value) error {
+	if receiver.index >= len(receiver.database.rows) {
+		if receiver.database.rowsError != nil {
+			return receiver.database.rowsError
+		}
+		return io.EOF
+	}
+
+	copy(dest, receiver.database.rows[receiver.index])
+	receiver.index++
+	return nil
+}
+
+func (receiver *fakeDatabase) closedRowsCount() int {
+	receiver.mutex.Lock()
+	defer receiver.mutex.Unlock()
+
+	return receiver.closedRows
+}
+
+type testDogCowRow struct {
+	Name    string `db:"dogcow_name"`
+	Moofs   int64
+	Ignored string `db:"-"`
+}
+
+func newTestDogCowDatabase() *fakeDatabase {
+	return &fakeDatabase{
+		columns: []string{"dogcow_name", "MOOFS", "extra"},
+		rows: [][]driver.Value{
+			{"Clarus", int64(3), "x"},
+			{"DogCow", int64(26), "y"},
+		},
+	}
+}
+
+func TestFromRowsWithScanStruct(t *testing.T) {
+	assert := assert.New(t)
+
+	database := newTestDogCowDatabase()
+	rows, err := database.open().Query("SELECT")
+	assert.Nil(err)
+
+	actualSlice, err := FromRows(rows, ScanStruct[testDogCowRow]()).Slice()
+
+	assert.Equal([]testDogCowRow{{Name: "Clarus", Moofs: 3}, {Name: "DogCow", Moofs: 26}}, actualSlice)
+	assert.Nil(err)
+	assert.Equal(1, database.closedRowsCount())
+}
+
+type TestDogCowMoofs struct {
+	Moofs int64
+}
+
+type testDogCowEmbeddedRow struct {
+	Name string `db:"dogcow_name"`
+	*TestDogCowMoofs
+}
+
+type testDogCowMoofs struct {
+	Moofs int64
+}
+
+type testDogCowUnexportedEmbeddedRow struct {
+	Name string `db:"dogcow_name"`
+	*testDogCowMoofs
+}
+
+func TestFromRowsWithScanStructAndEmbeddedPointer(t *testing.T) {
+	assert := assert.New(t)
+
+	rows, err := newTestDogCowDatabase().open().Query("SELECT")
+	assert.Nil(err)
+
+	actualSlice, err := FromRows(rows, ScanStruct[testDogCowEmbeddedRow]()).Slice()
+
+	assert.Equal([]testDogCowEmbeddedRow{
+		{Name: "Clarus", TestDogCowMoofs: &TestDogCowMoofs{Moofs: 3}},
+		{Name: "DogCow", TestDogCowMoofs: &TestDogCowMoofs{Moofs: 26}},
+	}, actualSlice)
+	assert.Nil(err)
+}
+
+func TestFromRowsWithScanStructAndUnexportedEmbeddedPointer(t *testing.T) {
+	assert := assert.New(t)
+
+	database := newTestDogCowDatabase()
+	rows, err := database.open().Query("SELECT")
+	assert.Nil(err)
+
+	actualSlice, err := FromRows(rows, ScanStruct[testDogCowUnexportedEmbeddedRow]()).Slice()
+
+	assert.Empty(actualSlice)
+	assert.ErrorContains(err, "unexported embedded")
+	assert.Equal(1, database.closedRowsCount())
+}
+
+func TestFromRowsWithScanFunction(t *testing.T) {
+	assert := assert.New(t)
+
+	database := newTestDogCowDatabase()
+	rows, err := database.open().Query("SELECT")
+	assert.Nil(err)
+
+	scan := func(rows *sql.Rows) (string, error) {
+		var name, extra string
+		var moofs int
+		err := rows.Scan(&name, &moofs, &extra)
+		return name, err
+	}
+
+	actualSlice, err := FromRows(rows, scan).Slice()
+
+	assert.Equal([]string{"Clarus", "DogCow"}, actualSlice)
+	assert.Nil(err)
+}
+
+func TestFromRowsHasRowsError(t *testing.T) {
+	assert := assert.New(t)
+
+	expectedError := errors.New("an example error")
+	database := newTestDogCowDatabase()
+	database.rowsError = expectedError
+	rows, err := database.open().Query("SELECT")
+	assert.Nil(err)
+
+	actualSlice, err := FromRows(rows, ScanStruct[testDogCowRow]()).Slice()
+
+	assert.Len(actualSlice, 2)
+	assert.ErrorIs(err, expectedError)
+	assert.Equal(1, database.closedRowsCount())
+}
+
+func TestFromRowsHasScanError(t *testing.T) {
+	assert := assert.New(t)
+
+	database := newTestDogCowDatabase()
+	rows, err := database.open().Query("SELECT")
+	assert.Nil(err)
+
+	_, err = FromRows(rows, ScanStruct[int]()).Slice()
+
+	assert.NotNil(err)
+	assert.Equal(1, database.closedRowsCount())
+}
+
+func TestFromRowsClosesWhenAbandoned(t *testing.T) {
+	assert := assert.New(t)
+
+	database := newTestDogCowDatabase()
+	rows, err := database.open().Query("SELECT")
+	assert.Nil(err)
+
+	first, err := FromRows(rows, ScanStruct[testDogCowRow]()).First()
+	assert.Equal("Clarus", first.Name)
+	assert.Nil(err)
+
+	assert.Eventually(func() bool {
+		runtime.GC()
+		return database.closedRowsCount() == 1
+	}, time.Second, 10*time.Millisecond)
+}
+
+func buildTestInsert(value int) (string, []any, error) {
+	return "INSERT INTO moofs VALUES (?)", []any{int64(value)}, nil
+}
+
+func TestWriteSQLBatches(t *testing.T) {
+	assert := assert.New(t)
+
+	database := &fakeDatabase{}
+
+	report, err := FromSlice([]int{1, 2, 3, 4, 5}).WriteSQLBatches(database.open(), 2, buildTestInsert, SQLBatchPolicy{})
+
+	assert.Nil(err)
+	assert.Equal(5, report.RowsWritten)
+	assert.Empty(report.FailedBatches)
+	assert.Equal(3, database.commits)
+	assert.Equal([][]driver.Value{{int64(1)}, {int64(2)}, {int64(3)}, {int64(4)}, {int64(5)}}, database.execs)
+}
+
+func TestWriteSQLBatchesWithStatsReportsOnlyTheChainStages(t *testing.T) {
+	assert := assert.New(t)
+
+	database := &fakeDatabase{}
+	link := FromSlice([]int{1, 2, 3, 4, 5}).
+		WithClock(newFakeClock()).
+		WithStats().
+		Map(func(value int) (int, error) { return value, nil })
+
+	_, err := link.WriteSQLBatches(database.open(), 2, buildTestInsert, SQLBatchPolicy{})
+
+	assert.Nil(err)
+	assert.Equal([]StageStats{
+		{Stage: "Map", Position: 1, Consumed: 5, Produced: 5},
+	}, link.Stats().Stages)
+}
+
+func TestWriteSQLBatchesStopsOnFailure(t *testing.T) {
+	assert := assert.New(t)
+
+	expectedError := errors.New("an example error")
+	database := &fakeDatabase{
+		execError: func(args []driver.Value) error {
+			if args[0] == int64(3) {
+				return expectedError
+			}
+			return nil
+		},
+	}
+
+	report, err := FromSlice([]int{1, 2, 3, 4, 5}).WriteSQLBatches(database.open(), 2, buildTestInsert, SQLBatchPolicy{})
+
+	assert.ErrorIs(err, expectedError)
+	assert.Equal(2, report.RowsWritten)
+	assert.Len(report.FailedBatches, 1)
+	assert.Equal(1, report.FailedBatches[0].Index)
+	assert.Equal([]int{3, 4}, report.FailedBatches[0].Values)
+	assert.Equal(1, database.rollbacks)
+	assert.Equal([][]driver.Value{{int64(1)}, {int64(2)}}, database.execs)
+}
+
+func TestWriteSQLBatchesContinuesOnFailure(t *testing.T) {
+	assert := assert.New(t)
+
+	expectedError := errors.New("an example error")
+	database := &fakeDatabase{
+		execError: func(args []driver.Value) error {
+			if args[0] == int64(3) {
+				return expectedError
+			}
+			return nil
+		},
+	}
+	policy := SQLBatchPolicy{Retries: 2, ContinueOnFailure: true}
+
+	report, err := FromSlice([]int{1, 2, 3, 4, 5}).WriteSQLBatches(database.open(), 2, buildTestInsert, policy)
+
+	assert.Nil(err)
+	assert.Equal(3, report.RowsWritten)
+	assert.Len(report.FailedBatches, 1)
+	assert.ErrorIs(report.FailedBatches[0].Err, expectedError)
+	assert.Equal(3, database.rollbacks)
+}
+
+func TestWriteSQLBatchesRetriesUntilSuccess(t *testing.T) {
+	assert := assert.New(t)
+
+	failuresLeft := 2
+	database := &fakeDatabase{
+		execError: func(args []driver.Value) error {
+			if failuresLeft > 0 {
+				failuresLeft--
+				return errors.New("an example error")
+			}
+			return nil
+		},
+	}
+
+	report, err := FromSlice([]int{1, 2}).WriteSQLBatches(database.open(), 5, buildTestInsert, SQLBatchPolicy{Retries: 2})
+
+	assert.Nil(err)
+	assert.Equal(2, report.RowsWritten)
+	assert.Equal(2, database.rollbacks)
+	assert.Equal(1, database.commits)
+}
+
+func TestWriteSQLBatchesDoesNotRetryUnretryable(t *testing.T) {
+	assert := assert.New(t)
+
+	expectedError := errors.New("an example error")
+	database := &fakeDatabase{
+		execError: func(args []driver.Value) error {
+			return expectedError
+		},
+	}
+	policy := SQLBatchPolicy{Retries: 5, Retryable: func(err error) bool { return false }}
+
+	_, err := FromSlice([]int{1, 2}).WriteSQLBatches(database.open(), 5, buildTestInsert, policy)
+
+	assert.ErrorIs(err, expectedError)
+	assert.Equal(1, database.rollbacks)
+}
+
+func TestWriteSQLBatchesHasChainError(t *testing.T) {
+	assert := assert.New(t)
+
+	expectedError := errors.New("an example error")
+	database := &fakeDatabase{}
+	link := newLink(createGeneratorWithError([]int{1, 2, 3}, 3, expectedError))
+
+	report, err := link.WriteSQLBatches(database.open(), 5, buildTestInsert, SQLBatchPolicy{})
+
+	assert.Equal(expectedError, err)
+	assert.Equal(2, report.RowsWritten)
+}
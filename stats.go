@@ -0,0 +1,134 @@
+package rangechain
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+)
+
+// StageStats is what one stage of the chain did, as gathered by `WithStats`.
+type StageStats struct {
+	// Stage is the name of the chaining method, like "Map", or the label set with `Label`.
+	Stage string
+	// Position is where the stage is in the chain, counting chaining methods from 1.
+	Position int
+	// Consumed is how many values the stage received from earlier in the chain.
+	Consumed int
+	// Produced is how many values the stage generated.
+	Produced int
+	// Errors is how many errors the stage generated, not counting errors it only passed along.
+	Errors int
+	// CallbackTime is the time spent in the functions passed to the stage. For parallel stages, it is the sum of the time each call took, across all goroutines. For other stages, it is the time the stage spent generating values other than waiting on earlier in the chain.
+	CallbackTime time.Duration
+	// UpstreamWait is the time the stage spent waiting on earlier in the chain for values.
+	UpstreamWait time.Duration
+	// Goroutines is how many goroutines a parallel stage, like `MapParallel`, started. It is zero for other stages.
+	Goroutines int
+}
+
+// ChainStats is what the chain did, as gathered by `WithStats`.
+type ChainStats struct {
+	// Stages are in chain order.
+	Stages []StageStats
+	// Elapsed is the time the terminating methods took.
+	Elapsed time.Duration
+}
+
+// String formats the statistics as a table with a row for each stage.
+func (receiver ChainStats) String() string {
+	var builder strings.Builder
+
+	writer := tabwriter.NewWriter(&builder, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(writer, "#\tstage\tconsumed\tproduced\terrors\tcallback\tupstream wait\tgoroutines")
+	for _, stage := range receiver.Stages {
+		_, _ = fmt.Fprintf(writer, "%d\t%s\t%d\t%d\t%d\t%v\t%v\t%d\n", stage.Position, stage.Stage, stage.Consumed, stage.Produced, stage.Errors, stage.CallbackTime, stage.UpstreamWait, stage.Goroutines)
+	}
+	_ = writer.Flush()
+
+	_, _ = fmt.Fprintf(&builder, "elapsed %v\n", receiver.Elapsed)
+
+	return builder.String()
+}
+
+// WithStats gathers statistics about the chaining methods called after it. Keep the last link of the chain and call `Stats` on it once the terminating method returns.
+func (receiver *Link[T]) WithStats() *Link[T] {
+	return receiver.Observe(&statsCollector{stages: map[stageKey]*stageTally{}})
+}
+
+// Stats returns the statistics gathered so far by the closest `WithStats` earlier in the chain. It is empty if there is none.
+func (receiver *Link[T]) Stats() ChainStats {
+	for index := len(receiver.observers) - 1; index >= 0; index-- {
+		collector, ok := receiver.observers[index].(*statsCollector)
+		if ok {
+			return collector.report()
+		}
+	}
+
+	return ChainStats{}
+}
+
+// statsCollector is the `Observer` behind `WithStats`.
+type statsCollector struct {
+	mutex   sync.Mutex
+	stages  map[stageKey]*stageTally
+	elapsed time.Duration
+}
+
+type stageTally struct {
+	StageStats
+	// generating is the time spent generating values and errors, which includes waiting on earlier in the chain
+	generating time.Duration
+}
+
+func (receiver *statsCollector) Observe(event StageEvent) {
+	receiver.mutex.Lock()
+	defer receiver.mutex.Unlock()
+
+	if event.Kind == ChainFinished {
+		receiver.elapsed += event.Duration
+		return
+	}
+
+	key := stageKey{position: event.Position, stage: event.Stage}
+	tally, ok := receiver.stages[key]
+	if !ok {
+		tally = &stageTally{StageStats: StageStats{Stage: event.Stage, Position: event.Position}}
+		receiver.stages[key] = tally
+	}
+
+	switch event.Kind {
+	case ElementIn:
+		tally.Consumed++
+		tally.UpstreamWait += event.Duration
+	case ElementOut:
+		tally.Produced++
+		tally.generating += event.Duration
+	case StageError:
+		tally.Errors++
+		tally.generating += event.Duration
+	case StageExhausted:
+		tally.generating += event.Duration
+	case ParallelCall:
+		tally.Goroutines++
+		tally.CallbackTime += event.Duration
+	}
+}
+
+func (receiver *statsCollector) report() ChainStats {
+	receiver.mutex.Lock()
+	defer receiver.mutex.Unlock()
+
+	stats := ChainStats{Elapsed: receiver.elapsed}
+	for _, key := range sortedStageKeys(receiver.stages) {
+		tally := receiver.stages[key]
+		stageStats := tally.StageStats
+		if stageStats.Goroutines == 0 {
+			stageStats.CallbackTime = max(tally.generating-tally.UpstreamWait, 0)
+		}
+		stats.Stages = append(stats.Stages, stageStats)
+	}
+
+	return stats
+}
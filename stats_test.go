@@ -0,0 +1,93 @@
+package rangechain
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithStats(t *testing.T) {
+	assert := assert.New(t)
+
+	clock := newFakeClock()
+	link := FromSlice([]int{1, 2, 3, 4}).
+		WithClock(clock).
+		WithStats().
+		Map(func(value int) (int, error) {
+			clock.Advance(time.Second)
+			return value * 2, nil
+		}).
+		Filter(func(value int) (bool, error) {
+			clock.Advance(500 * time.Millisecond)
+			return value > 4, nil
+		})
+
+	actualSlice, err := link.Slice()
+
+	assert.Equal([]int{6, 8}, actualSlice)
+	assert.Nil(err)
+	assert.Equal(ChainStats{
+		Stages: []StageStats{
+			{Stage: "Map", Position: 1, Consumed: 4, Produced: 4, CallbackTime: 4 * time.Second},
+			{Stage: "Filter", Position: 2, Consumed: 4, Produced: 2, CallbackTime: 2 * time.Second, UpstreamWait: 4 * time.Second},
+		},
+		Elapsed: 6 * time.Second,
+	}, link.Stats())
+}
+
+func TestWithStatsCountsGoroutines(t *testing.T) {
+	assert := assert.New(t)
+
+	link := FromSlice([]string{"1", "DogCow", "3"}).
+		WithClock(newFakeClock()).
+		WithStats().
+		OnError(SkipAndContinue()).
+		MapParallel(strconv.Atoi).
+		FilterParallel(func(value int) (bool, error) { return value > 1, nil })
+
+	actualSlice, err := link.Slice()
+
+	assert.Equal([]int{3}, actualSlice)
+	assert.Nil(err)
+	assert.Equal([]StageStats{
+		{Stage: "MapParallel", Position: 1, Consumed: 3, Produced: 2, Goroutines: 3},
+		{Stage: "FilterParallel", Position: 2, Consumed: 2, Produced: 1, Goroutines: 2},
+	}, link.Stats().Stages)
+}
+
+func TestWithStatsCountsErrors(t *testing.T) {
+	assert := assert.New(t)
+
+	link := FromSlice([]string{"1", "DogCow"}).WithClock(newFakeClock()).WithStats().Map(strconv.Atoi).Limit(5)
+
+	_, err := link.Slice()
+
+	assert.ErrorIs(err, strconv.ErrSyntax)
+	stats := link.Stats()
+	assert.Equal(1, stats.Stages[0].Errors)
+	assert.Equal(0, stats.Stages[1].Errors)
+}
+
+func TestStatsWithoutWithStats(t *testing.T) {
+	link := FromSlice([]int{1, 2, 3}).Map(func(value int) (int, error) { return value, nil })
+
+	_, _ = link.Slice()
+
+	assert.Equal(t, ChainStats{}, link.Stats())
+}
+
+func TestChainStatsString(t *testing.T) {
+	stats := ChainStats{
+		Stages:  []StageStats{{Stage: "Map", Position: 1, Consumed: 4, Produced: 4, CallbackTime: time.Second}},
+		Elapsed: time.Second,
+	}
+
+	expected := "" +
+		"#  stage  consumed  produced  errors  callback  upstream wait  goroutines\n" +
+		"1  Map    4         4         0       1s        0s             0\n" +
+		"elapsed 1s\n"
+
+	assert.Equal(t, expected, stats.String())
+}
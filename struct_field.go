@@ -0,0 +1,26 @@
+package rangechain
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// settableField returns the field of `structValue` at `index`, as found by `reflect.VisibleFields`. A nil pointer to an embedded struct on the way is allocated so that a field promoted through it can be set.
+func settableField(structValue reflect.Value, index []int) (reflect.Value, error) {
+	fieldValue := structValue
+	for position, fieldIndex := range index {
+		if position > 0 && fieldValue.Kind() == reflect.Pointer {
+			if fieldValue.IsNil() {
+				if !fieldValue.CanSet() {
+					return reflect.Value{}, fmt.Errorf("cannot allocate the unexported embedded %v", fieldValue.Type())
+				}
+				fieldValue.Set(reflect.New(fieldValue.Type().Elem()))
+			}
+			fieldValue = fieldValue.Elem()
+		}
+
+		fieldValue = fieldValue.Field(fieldIndex)
+	}
+
+	return fieldValue, nil
+}
@@ -0,0 +1,102 @@
+package rangechain
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// TimeoutError is generated when `MapTimeout` or `Deadline` run out of time. `errors.Is` reports it as `context.DeadlineExceeded`.
+type TimeoutError struct {
+	// Value is the value that ran out of time. It is nil when `Deadline` ran out of time while waiting for the next value.
+	Value any
+	// Timeout is the duration given to `MapTimeout`. It is zero for `Deadline`.
+	Timeout time.Duration
+	// Deadline is when time ran out.
+	Deadline time.Time
+}
+
+func (receiver *TimeoutError) Error() string {
+	if receiver.Timeout > 0 {
+		return fmt.Sprintf("element (%v) took longer than %v: %v", receiver.Value, receiver.Timeout, context.DeadlineExceeded)
+	} else if receiver.Value == nil {
+		return fmt.Sprintf("chain not finished by %v: %v", receiver.Deadline.Format(time.RFC3339Nano), context.DeadlineExceeded)
+	}
+
+	return fmt.Sprintf("element (%v) not ready by %v: %v", receiver.Value, receiver.Deadline.Format(time.RFC3339Nano), context.DeadlineExceeded)
+}
+
+func (receiver *TimeoutError) Unwrap() error {
+	return context.DeadlineExceeded
+}
+
+// MapTimeout is like Map, but a call to `mapFunction` that doesn't return within `timeout` fails with a `*TimeoutError` carrying the value. The chain moves on without waiting for the call, which keeps running in the background until it returns.
+func (receiver *Link[T]) MapTimeout[U any](timeout time.Duration, mapFunction func(T) (U, error)) *Link[U] {
+	return receiver.Label(receiver.stageName("MapTimeout")).Map(timingOut(receiver.currentClock(), timeout, mapFunction, nil))
+}
+
+// MapTimeoutWithFallback is like MapTimeout, but a call to `mapFunction` that doesn't return within `timeout` is replaced by calling `fallback` with the same value.
+func (receiver *Link[T]) MapTimeoutWithFallback[U any](timeout time.Duration, mapFunction func(T) (U, error), fallback func(T) (U, error)) *Link[U] {
+	return receiver.Label(receiver.stageName("MapTimeoutWithFallback")).Map(timingOut(receiver.currentClock(), timeout, mapFunction, fallback))
+}
+
+// timingOut wraps `function` so that it gives up after `timeout`, calling `fallback` instead if it isn't nil.
+func timingOut[T, U any](clock Clock, timeout time.Duration, function func(T) (U, error), fallback func(T) (U, error)) func(T) (U, error) {
+	return func(value T) (U, error) {
+		start := clock.Now()
+		results := make(chan arrival[U], 1)
+
+		go func() {
+			result, err := function(value)
+			results <- arrival[U]{value: result, err: err}
+		}()
+
+		select {
+		case result := <-results:
+			return result.value, result.err
+		case <-clock.After(timeout):
+			if fallback != nil {
+				return fallback(value)
+			}
+
+			var zero U
+			return zero, &TimeoutError{Value: value, Timeout: timeout, Deadline: start.Add(timeout)}
+		}
+	}
+}
+
+// Deadline stops the chain at `deadline`. Waiting for a value from earlier in the chain is abandoned at `deadline`, and from then on the chain generates a `*TimeoutError`, which `errors.Is` reports as `context.DeadlineExceeded`.
+func (receiver *Link[T]) Deadline(deadline time.Time) *Link[T] {
+	clock := receiver.currentClock()
+	var expired error
+
+	deadlineGenerator := func() (T, error) {
+		var zero T
+
+		if expired != nil {
+			return zero, expired
+		}
+
+		remaining := deadline.Sub(clock.Now())
+		if remaining <= 0 {
+			expired = &TimeoutError{Deadline: deadline}
+			return zero, expired
+		}
+
+		results := make(chan arrival[T], 1)
+		go func() {
+			value, err := receiver.generator()
+			results <- arrival[T]{value: value, err: err}
+		}()
+
+		select {
+		case result := <-results:
+			return result.value, result.err
+		case <-clock.After(remaining):
+			expired = &TimeoutError{Deadline: deadline}
+			return zero, expired
+		}
+	}
+
+	return newChainedLink(receiver, "Deadline", deadlineGenerator)
+}
@@ -0,0 +1,132 @@
+package rangechain
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMapTimeout(t *testing.T) {
+	assert := assert.New(t)
+
+	actualSlice, err := FromSlice([]string{"1", "2", "3"}).WithClock(newFakeClock()).MapTimeout(time.Second, strconv.Atoi).Slice()
+
+	assert.Equal([]int{1, 2, 3}, actualSlice)
+	assert.Nil(err)
+}
+
+// hangOnTwo returns a map function that multiplies by ten, except for 2, where it moves `clock` past the timeout of the call and then hangs until `hung` is closed.
+func hangOnTwo(t *testing.T, clock *fakeClock, hung <-chan struct{}) func(int) (int, error) {
+	return func(value int) (int, error) {
+		if value == 2 {
+			assert.Eventually(t, func() bool { return clock.AfterCalls() == 2 }, time.Second, time.Millisecond)
+			clock.Advance(time.Second)
+			<-hung
+		}
+		return value * 10, nil
+	}
+}
+
+func TestMapTimeoutTimesOut(t *testing.T) {
+	assert := assert.New(t)
+
+	clock := newFakeClock()
+	hung := make(chan struct{})
+	defer close(hung)
+	mapFunction := hangOnTwo(t, clock, hung)
+
+	actualSlice, err := FromSlice([]int{1, 2, 3}).WithClock(clock).MapTimeout(time.Second, mapFunction).Slice()
+
+	assert.Equal([]int{10}, actualSlice)
+	assert.ErrorIs(err, context.DeadlineExceeded)
+
+	var timeoutError *TimeoutError
+	assert.ErrorAs(err, &timeoutError)
+	assert.Equal(2, timeoutError.Value)
+	assert.Equal(time.Second, timeoutError.Timeout)
+
+	var chainError *ChainError
+	assert.ErrorAs(err, &chainError)
+	assert.Equal("MapTimeout", chainError.Stage)
+	assert.Equal(1, chainError.Index)
+}
+
+func TestMapTimeoutWithFallback(t *testing.T) {
+	assert := assert.New(t)
+
+	clock := newFakeClock()
+	hung := make(chan struct{})
+	defer close(hung)
+	mapFunction := hangOnTwo(t, clock, hung)
+	fallback := func(value int) (int, error) {
+		return -value, nil
+	}
+
+	actualSlice, err := FromSlice([]int{1, 2, 3}).WithClock(clock).MapTimeoutWithFallback(time.Second, mapFunction, fallback).Slice()
+
+	assert.Equal([]int{10, -2, 30}, actualSlice)
+	assert.Nil(err)
+}
+
+func TestMapTimeoutHasError(t *testing.T) {
+	assert := assert.New(t)
+
+	actualSlice, err := FromSlice([]string{"1", "DogCow", "3"}).WithClock(newFakeClock()).MapTimeout(time.Second, strconv.Atoi).Slice()
+
+	assert.Equal([]int{1}, actualSlice)
+	assert.ErrorIs(err, strconv.ErrSyntax)
+}
+
+func TestDeadline(t *testing.T) {
+	assert := assert.New(t)
+
+	clock := newFakeClock()
+	deadline := clock.Now().Add(time.Second)
+	link := FromSlice([]int{1, 2, 3, 4}).WithClock(clock).Deadline(deadline)
+
+	actualSlice, err := link.Limit(2).Slice()
+	assert.Equal([]int{1, 2}, actualSlice)
+	assert.Nil(err)
+
+	clock.Advance(2 * time.Second)
+
+	actualSlice, err = link.Slice()
+
+	assert.Equal([]int{}, actualSlice)
+	assert.ErrorIs(err, context.DeadlineExceeded)
+
+	var timeoutError *TimeoutError
+	assert.ErrorAs(err, &timeoutError)
+	assert.Equal(deadline, timeoutError.Deadline)
+	assert.Nil(timeoutError.Value)
+	assert.Equal("chain not finished by "+deadline.Format(time.RFC3339Nano)+": context deadline exceeded", err.Error())
+}
+
+func TestDeadlineAbandonsHungSource(t *testing.T) {
+	assert := assert.New(t)
+
+	clock := newFakeClock()
+	clock.autoAdvance = true
+	channel := make(chan int)
+
+	actualSlice, err := FromChannel(channel).WithClock(clock).Deadline(clock.Now().Add(time.Minute)).Slice()
+
+	assert.Equal([]int{}, actualSlice)
+	assert.ErrorIs(err, context.DeadlineExceeded)
+}
+
+func TestDeadlineHasError(t *testing.T) {
+	assert := assert.New(t)
+
+	expectedError := errors.New("an example error")
+	link := newLink(createGeneratorWithError([]int{1, 2, 3}, 2, expectedError))
+
+	actualSlice, err := link.WithClock(newFakeClock()).Deadline(time.Now().Add(time.Hour)).Slice()
+
+	assert.Equal([]int{1}, actualSlice)
+	assert.Equal(expectedError, err)
+}
@@ -0,0 +1,101 @@
+package rangechain
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// Trace logs what the chaining methods called after it did once the terminating method finishes. For each stage, a record at `level` gives the stage's name, like "Map" or "Sort", its position, how many values it received and generated, how many errors it generated along with the first one, and the time it spent generating values, which includes waiting on earlier in the chain. A final record gives the time the terminating method took. When `logger` is enabled for debug, every value and error is also logged at debug level as it is generated.
+func (receiver *Link[T]) Trace(logger *slog.Logger, level slog.Level) *Link[T] {
+	return receiver.Observe(&tracer{logger: logger, level: level, stages: map[stageKey]*traceStage{}})
+}
+
+// tracer is the `Observer` behind `Trace`. It tallies each stage until the chain finishes.
+type tracer struct {
+	logger *slog.Logger
+	level  slog.Level
+
+	mutex  sync.Mutex
+	stages map[stageKey]*traceStage
+}
+
+type traceStage struct {
+	received   int
+	generated  int
+	errors     int
+	firstError error
+	elapsed    time.Duration
+}
+
+func (receiver *tracer) Observe(event StageEvent) {
+	ctx := context.Background()
+
+	if event.Kind == ChainFinished {
+		receiver.logSummary(ctx, event)
+		return
+	}
+
+	receiver.mutex.Lock()
+	key := stageKey{position: event.Position, stage: event.Stage}
+	stage, ok := receiver.stages[key]
+	if !ok {
+		stage = &traceStage{}
+		receiver.stages[key] = stage
+	}
+
+	switch event.Kind {
+	case ElementIn:
+		stage.received++
+	case ElementOut:
+		stage.generated++
+		stage.elapsed += event.Duration
+	case StageError:
+		stage.errors++
+		if stage.firstError == nil {
+			stage.firstError = event.Err
+		}
+		stage.elapsed += event.Duration
+	case StageExhausted:
+		stage.elapsed += event.Duration
+	}
+	receiver.mutex.Unlock()
+
+	if (event.Kind == ElementOut || event.Kind == StageError) && receiver.logger.Enabled(ctx, slog.LevelDebug) {
+		attributes := []slog.Attr{slog.String("stage", event.Stage), slog.Int("position", event.Position)}
+		if event.Kind == ElementOut {
+			attributes = append(attributes, slog.Any("value", event.Value))
+		} else {
+			attributes = append(attributes, slog.Any("error", event.Err))
+		}
+		receiver.logger.LogAttrs(ctx, slog.LevelDebug, "rangechain element", attributes...)
+	}
+}
+
+// logSummary logs every stage seen since the last summary, in chain order, and then starts over.
+func (receiver *tracer) logSummary(ctx context.Context, finished StageEvent) {
+	receiver.mutex.Lock()
+	stages := receiver.stages
+	receiver.stages = map[stageKey]*traceStage{}
+	receiver.mutex.Unlock()
+
+	keys := sortedStageKeys(stages)
+	for _, key := range keys {
+		stage := stages[key]
+		attributes := []slog.Attr{
+			slog.String("stage", key.stage),
+			slog.Int("position", key.position),
+			slog.Int("received", stage.received),
+			slog.Int("generated", stage.generated),
+			slog.Int("errors", stage.errors),
+			slog.Duration("elapsed", stage.elapsed),
+		}
+		if stage.firstError != nil {
+			attributes = append(attributes, slog.Any("error", stage.firstError))
+		}
+		receiver.logger.LogAttrs(ctx, receiver.level, "rangechain stage", attributes...)
+	}
+
+	receiver.logger.LogAttrs(ctx, receiver.level, "rangechain finished", slog.Int("stages", len(keys)), slog.Duration("elapsed", finished.Duration))
+}
@@ -0,0 +1,104 @@
+package rangechain
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestLogger logs JSON without times to `output`.
+func newTestLogger(output *bytes.Buffer, level slog.Level) *slog.Logger {
+	return slog.New(slog.NewJSONHandler(output, &slog.HandlerOptions{
+		Level: level,
+		ReplaceAttr: func(groups []string, attribute slog.Attr) slog.Attr {
+			if attribute.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return attribute
+		},
+	}))
+}
+
+func decodeLogRecords(t *testing.T, output *bytes.Buffer) []map[string]any {
+	var records []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(output.String()), "\n") {
+		var record map[string]any
+		assert.Nil(t, json.Unmarshal([]byte(line), &record))
+		records = append(records, record)
+	}
+
+	return records
+}
+
+func TestTrace(t *testing.T) {
+	assert := assert.New(t)
+
+	clock := newFakeClock()
+	var output bytes.Buffer
+
+	actualSlice, err := FromSlice([]int{3, 1, 2, 1}).
+		WithClock(clock).
+		Trace(newTestLogger(&output, slog.LevelInfo), slog.LevelInfo).
+		DistinctFunc(func(value int) int { return value }).
+		Map(func(value int) (int, error) {
+			clock.Advance(time.Millisecond)
+			return value * 10, nil
+		}).
+		Sort(func(values []int) func(int, int) bool {
+			return func(i int, j int) bool { return values[i] < values[j] }
+		}).
+		Slice()
+
+	assert.Equal([]int{10, 20, 30}, actualSlice)
+	assert.Nil(err)
+	assert.Equal([]map[string]any{
+		{"level": "INFO", "msg": "rangechain stage", "stage": "DistinctFunc", "position": 1.0, "received": 4.0, "generated": 3.0, "errors": 0.0, "elapsed": 0.0},
+		{"level": "INFO", "msg": "rangechain stage", "stage": "Map", "position": 2.0, "received": 3.0, "generated": 3.0, "errors": 0.0, "elapsed": 3e6},
+		{"level": "INFO", "msg": "rangechain stage", "stage": "Sort", "position": 3.0, "received": 3.0, "generated": 3.0, "errors": 0.0, "elapsed": 3e6},
+		// Sort serialized the chain when it was called, so the terminating method had nothing left to wait on
+		{"level": "INFO", "msg": "rangechain finished", "stages": 3.0, "elapsed": 0.0},
+	}, decodeLogRecords(t, &output))
+}
+
+func TestTraceHasError(t *testing.T) {
+	assert := assert.New(t)
+
+	var output bytes.Buffer
+
+	_, err := FromSlice([]string{"1", "DogCow"}).
+		WithClock(newFakeClock()).
+		Trace(newTestLogger(&output, slog.LevelInfo), slog.LevelWarn).
+		Map(strconv.Atoi).
+		Slice()
+
+	records := decodeLogRecords(t, &output)
+	assert.Len(records, 2)
+	assert.Equal("WARN", records[0]["level"])
+	assert.Equal("Map", records[0]["stage"])
+	assert.Equal(1.0, records[0]["errors"])
+	assert.Equal(err.Error(), records[0]["error"])
+}
+
+func TestTraceLogsElementsAtDebug(t *testing.T) {
+	assert := assert.New(t)
+
+	var output bytes.Buffer
+
+	_, err := FromSlice([]string{"1", "2"}).
+		WithClock(newFakeClock()).
+		Trace(newTestLogger(&output, slog.LevelDebug), slog.LevelInfo).
+		Map(strconv.Atoi).
+		Slice()
+
+	assert.Nil(err)
+	records := decodeLogRecords(t, &output)
+	assert.Len(records, 4)
+	assert.Equal(map[string]any{"level": "DEBUG", "msg": "rangechain element", "stage": "Map", "position": 1.0, "value": 1.0}, records[0])
+	assert.Equal(map[string]any{"level": "DEBUG", "msg": "rangechain element", "stage": "Map", "position": 1.0, "value": 2.0}, records[1])
+}